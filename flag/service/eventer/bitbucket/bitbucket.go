@@ -0,0 +1,8 @@
+package bitbucket
+
+type Bitbucket struct {
+	OAuthToken   string
+	Organisation string
+	PollInterval string
+	Projects     string
+}
@@ -0,0 +1,17 @@
+package eventer
+
+import (
+	"github.com/giantswarm/draughtsman-eventer/flag/service/eventer/bitbucket"
+	"github.com/giantswarm/draughtsman-eventer/flag/service/eventer/github"
+	"github.com/giantswarm/draughtsman-eventer/flag/service/eventer/gitlab"
+	"github.com/giantswarm/draughtsman-eventer/flag/service/eventer/webhook"
+)
+
+type Eventer struct {
+	Bitbucket   bitbucket.Bitbucket
+	Environment string
+	GitHub      github.GitHub
+	GitLab      gitlab.GitLab
+	Type        string
+	Webhook     webhook.Webhook
+}
@@ -0,0 +1,9 @@
+package github
+
+type GitHub struct {
+	CircuitBreakerThreshold string
+	OAuthToken              string
+	Organisation            string
+	PollInterval            string
+	Projects                string
+}
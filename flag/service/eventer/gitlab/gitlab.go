@@ -0,0 +1,8 @@
+package gitlab
+
+type GitLab struct {
+	BaseURL      string
+	OAuthToken   string
+	PollInterval string
+	Projects     string
+}
@@ -0,0 +1,7 @@
+package webhook
+
+type Webhook struct {
+	Enabled       string
+	ListenAddress string
+	Secret        string
+}
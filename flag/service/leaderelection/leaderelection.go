@@ -0,0 +1,8 @@
+package leaderelection
+
+type LeaderElection struct {
+	Enabled   string
+	Identity  string
+	LockName  string
+	Namespace string
+}
@@ -4,10 +4,14 @@ import (
 	"github.com/giantswarm/draughtsman-eventer/flag/service/eventer"
 	"github.com/giantswarm/draughtsman-eventer/flag/service/httpclient"
 	"github.com/giantswarm/draughtsman-eventer/flag/service/kubernetes"
+	"github.com/giantswarm/draughtsman-eventer/flag/service/leaderelection"
+	"github.com/giantswarm/draughtsman-eventer/flag/service/log"
 )
 
 type Service struct {
-	Eventer    eventer.Eventer
-	HTTPClient httpclient.HTTPClient
-	Kubernetes kubernetes.Kubernetes
+	Eventer        eventer.Eventer
+	HTTPClient     httpclient.HTTPClient
+	Kubernetes     kubernetes.Kubernetes
+	LeaderElection leaderelection.LeaderElection
+	Log            log.Log
 }
@@ -0,0 +1,60 @@
+package log
+
+import (
+	"context"
+
+	"github.com/giantswarm/micrologger"
+)
+
+type contextKey struct{}
+
+// NewContext returns a context carrying logger, retrievable with FromContext.
+func NewContext(ctx context.Context, logger micrologger.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx by NewContext, or fallback if
+// ctx does not carry one.
+func FromContext(ctx context.Context, fallback micrologger.Logger) micrologger.Logger {
+	if l, ok := ctx.Value(contextKey{}).(micrologger.Logger); ok {
+		return l
+	}
+
+	return fallback
+}
+
+// With returns a context whose logger has keyVals appended to every Log call
+// made through it for the remainder of ctx's scope, e.g. project name,
+// environment, or deployment ID.
+func With(ctx context.Context, fallback micrologger.Logger, keyVals ...interface{}) context.Context {
+	return NewContext(ctx, &fieldLogger{
+		base:   FromContext(ctx, fallback),
+		fields: keyVals,
+	})
+}
+
+// fieldLogger appends a fixed set of key/value pairs to every Log call, so
+// fields picked up via With do not need to be repeated at each call site.
+type fieldLogger struct {
+	base   micrologger.Logger
+	fields []interface{}
+}
+
+func (l *fieldLogger) Log(keyVals ...interface{}) {
+	l.base.Log(append(append([]interface{}{}, keyVals...), l.fields...)...)
+}
+
+// LogCtx is the same as Log but additionally takes a context, for parity
+// with micrologger.Logger.
+func (l *fieldLogger) LogCtx(ctx context.Context, keyVals ...interface{}) {
+	l.Log(keyVals...)
+}
+
+// With returns a Logger with keyVals appended to l's existing fields, so
+// nested log.With calls accumulate rather than replace.
+func (l *fieldLogger) With(keyVals ...interface{}) micrologger.Logger {
+	return &fieldLogger{
+		base:   l.base,
+		fields: append(append([]interface{}{}, l.fields...), keyVals...),
+	}
+}
@@ -0,0 +1,104 @@
+// Package log provides a structured, context-aware logger built on
+// go.uber.org/zap. Logger implements micrologger.Logger, so it is a drop-in
+// replacement for the existing key/value Config.Logger fields while giving
+// operators configurable levels and JSON/console encoding.
+package log
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Config represents the configuration used to create a Logger.
+type Config struct {
+	// Encoding selects the zapcore encoder, "json" or "console".
+	Encoding string
+	// Level is the minimum enabled log level, e.g. "debug", "info", "warn", "error".
+	Level string
+}
+
+// DefaultConfig provides a default configuration to create a new Logger by
+// best effort.
+func DefaultConfig() Config {
+	return Config{
+		Encoding: "json",
+		Level:    "info",
+	}
+}
+
+// Logger wraps a zap.SugaredLogger behind the micrologger.Logger interface,
+// so callers that only know how to log "key", value pairs get structured,
+// leveled output for free.
+type Logger struct {
+	sugared *zap.SugaredLogger
+}
+
+// New creates a new configured Logger.
+func New(config Config) (*Logger, error) {
+	if config.Encoding == "" {
+		config.Encoding = "json"
+	}
+	if config.Level == "" {
+		config.Level = "info"
+	}
+
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(config.Level)); err != nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Level must be a valid zap level: %s", err)
+	}
+
+	zapConfig := zap.NewProductionConfig()
+	zapConfig.Encoding = config.Encoding
+	zapConfig.Level = zap.NewAtomicLevelAt(level)
+
+	zapLogger, err := zapConfig.Build()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return &Logger{sugared: zapLogger.Sugar()}, nil
+}
+
+// Log implements micrologger.Logger. keyVals follows the convention already
+// used throughout this codebase: the level comes first, the message second,
+// followed by further key/value pairs, e.g.
+// logger.Log("debug", "found new deployment", "project", "api").
+func (l *Logger) Log(keyVals ...interface{}) {
+	if len(keyVals) < 2 {
+		l.sugared.Infow("")
+		return
+	}
+
+	level, _ := keyVals[0].(string)
+	message, _ := keyVals[1].(string)
+	fields := keyVals[2:]
+
+	switch level {
+	case "debug":
+		l.sugared.Debugw(message, fields...)
+	case "warning", "warn":
+		l.sugared.Warnw(message, fields...)
+	case "error":
+		l.sugared.Errorw(message, fields...)
+	default:
+		l.sugared.Infow(message, fields...)
+	}
+}
+
+// LogCtx is the same as Log but additionally takes a context, for parity
+// with micrologger.Logger. ctx is otherwise unused here: context-scoped
+// fields are carried via pkg/log's own With/FromContext instead of
+// loggermeta, so there is nothing further to extract from it.
+func (l *Logger) LogCtx(ctx context.Context, keyVals ...interface{}) {
+	l.Log(keyVals...)
+}
+
+// With returns a Logger that includes keyVals on every subsequent Log call,
+// in addition to whatever that call passes itself.
+func (l *Logger) With(keyVals ...interface{}) micrologger.Logger {
+	return &Logger{sugared: l.sugared.With(keyVals...)}
+}
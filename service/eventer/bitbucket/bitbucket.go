@@ -0,0 +1,178 @@
+package bitbucket
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+
+	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
+	httpspec "github.com/giantswarm/draughtsman-eventer/service/http"
+)
+
+var (
+	// BitbucketEventerType is an Eventer that uses Bitbucket Deployments as a
+	// backend.
+	BitbucketEventerType eventerspec.EventerType = "BitbucketEventer"
+)
+
+// Config represents the configuration used to create a Bitbucket Eventer.
+type Config struct {
+	// Dependencies.
+	HTTPClient httpspec.Client
+	Logger     micrologger.Logger
+
+	// Settings.
+	OAuthToken   string
+	Organisation string
+	PollInterval time.Duration
+}
+
+// DefaultConfig provides a default configuration to create a new Bitbucket
+// Eventer by best effort.
+func DefaultConfig() Config {
+	return Config{
+		// Dependencies.
+		HTTPClient: nil,
+		Logger:     nil,
+
+		// Settings.
+		OAuthToken:   "",
+		Organisation: "",
+		PollInterval: 0,
+	}
+}
+
+// Eventer is an implementation of the Eventer interface, that uses Bitbucket
+// Deployments as a backend.
+type Eventer struct {
+	// Dependencies.
+	client httpspec.Client
+	logger micrologger.Logger
+
+	// Internals.
+	etagMap map[string]string
+
+	// Settings.
+	oauthToken   string
+	organisation string
+	pollInterval time.Duration
+}
+
+// New creates a new configured Bitbucket Eventer.
+func New(config Config) (*Eventer, error) {
+	// Dependencies.
+	if config.HTTPClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.HTTPClient must not be empty")
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Logger must not be empty")
+	}
+
+	// Settings.
+	if config.OAuthToken == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.OAuthToken must not be empty")
+	}
+	if config.Organisation == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.Organisation must not be empty")
+	}
+	if config.PollInterval.Seconds() == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "config.PollInterval must be greater than zero")
+	}
+
+	eventer := &Eventer{
+		// Dependencies.
+		client: config.HTTPClient,
+		logger: config.Logger,
+
+		// Internals.
+		etagMap: map[string]string{},
+
+		// Settings.
+		oauthToken:   config.OAuthToken,
+		organisation: config.Organisation,
+		pollInterval: config.PollInterval,
+	}
+
+	return eventer, nil
+}
+
+func (e *Eventer) FetchContinuously(ctx context.Context, projects []string, environment string) (<-chan eventerspec.DeploymentEvent, error) {
+	e.logger.Log("debug", "starting polling for bitbucket deployment events", "interval", e.pollInterval)
+
+	deploymentEventChannel := make(chan eventerspec.DeploymentEvent)
+	ticker := time.NewTicker(e.pollInterval)
+
+	go func() {
+		defer ticker.Stop()
+		defer close(deploymentEventChannel)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, p := range projects {
+					d, err := e.fetchLatest(p, environment, true)
+					if err != nil {
+						continue
+					}
+
+					select {
+					case deploymentEventChannel <- d:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return deploymentEventChannel, nil
+}
+
+func (e *Eventer) FetchLatest(project, environment string) (eventerspec.DeploymentEvent, error) {
+	d, err := e.fetchLatest(project, environment, false)
+	if err != nil {
+		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
+	}
+
+	return d, nil
+}
+
+func (e *Eventer) SetPendingStatus(event eventerspec.DeploymentEvent) error {
+	return e.postDeploymentStatus(event.Name, event.Sha, pendingState)
+}
+
+func (e *Eventer) fetchLatest(project, environment string, filterPending bool) (eventerspec.DeploymentEvent, error) {
+	e.logger.Log("debug", "fetching latest deployment", "project", project)
+
+	deployments, err := e.fetchNewDeploymentEvents(project, environment, e.etagMap, filterPending)
+	if IsNotFound(err) {
+		e.logger.Log("debug", "no new deployment events", "project", project)
+		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
+	} else if err != nil {
+		e.logger.Log("error", "could not fetch deployment events", "message", err.Error())
+		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
+	}
+
+	d := deployments[0]
+
+	return eventerspec.DeploymentEvent{
+		// Bitbucket identifies deployments by UUID rather than an integer, so
+		// we fold it down to an int to fit the common DeploymentEvent shape.
+		ID:   uuidToInt(d.UUID),
+		Name: project,
+		Sha:  d.Release.Commit.Hash,
+	}, nil
+}
+
+// uuidToInt folds a Bitbucket deployment UUID down to an int, so it can be
+// carried in the common eventerspec.DeploymentEvent shape.
+func uuidToInt(uuid string) int {
+	h := fnv.New32a()
+	h.Write([]byte(uuid))
+	return int(h.Sum32())
+}
@@ -0,0 +1,180 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	// deploymentsUrlFormat is the string format for the Bitbucket API call
+	// listing Deployments for a repository.
+	// See: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-deployments/
+	deploymentsUrlFormat = "https://api.bitbucket.org/2.0/repositories/%s/%s/deployments/"
+
+	// etagHeader is the header used for etag.
+	// See: https://en.wikipedia.org/wiki/HTTP_ETag.
+	etagHeader = "Etag"
+)
+
+// deployment represents a Bitbucket deployment.
+type deployment struct {
+	UUID        string `json:"uuid"`
+	Environment struct {
+		Name string `json:"name"`
+	} `json:"environment"`
+	Release struct {
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"release"`
+	State struct {
+		Name string `json:"name"`
+	} `json:"state"`
+}
+
+// deploymentPage is the paginated response Bitbucket wraps deployments in.
+type deploymentPage struct {
+	Values []deployment `json:"values"`
+}
+
+// deploymentStatusState represents the state of a Deployment Status.
+type deploymentStatusState string
+
+const (
+	pendingState deploymentStatusState = "IN_PROGRESS"
+	successState deploymentStatusState = "SUCCESSFUL"
+	failureState deploymentStatusState = "FAILED"
+)
+
+func (d deployment) isPending() bool {
+	return d.State.Name == "" || deploymentStatusState(d.State.Name) == pendingState
+}
+
+// request makes a request against the Bitbucket API, authenticating with the
+// configured OAuth token.
+func (e *Eventer) request(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", e.oauthToken))
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return resp, nil
+}
+
+// fetchNewDeploymentEvents fetches any new Bitbucket Deployments for the
+// given project, using the given etagMap to avoid refetching unchanged
+// results.
+func (e *Eventer) fetchNewDeploymentEvents(project, environment string, etagMap map[string]string, filterPending bool) ([]deployment, error) {
+	var err error
+
+	var u *url.URL
+	{
+		u, err = url.Parse(fmt.Sprintf(deploymentsUrlFormat, e.organisation, project))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		q := u.Query()
+		q.Set("sort", "-environment.name")
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// If we have an etag header for this project, then we have already
+	// requested deployments for it, so only ask for changes.
+	if val, ok := etagMap[project]; ok {
+		req.Header.Set("If-None-Match", val)
+	}
+
+	resp, err := e.request(req)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	defer resp.Body.Close()
+
+	etagMap[project] = resp.Header.Get(etagHeader)
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, microerror.Mask(notFoundError)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, microerror.Maskf(unexpectedStatusCode, fmt.Sprintf("received non-200 status code: %v", resp.StatusCode))
+	}
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var page deploymentPage
+	if err := json.Unmarshal(bytes, &page); err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	deployments := []deployment{}
+	for _, d := range page.Values {
+		if d.Environment.Name != environment {
+			continue
+		}
+		if filterPending && !d.isPending() {
+			continue
+		}
+		deployments = append(deployments, d)
+	}
+
+	if len(deployments) == 0 {
+		return nil, microerror.Mask(notFoundError)
+	}
+
+	return deployments, nil
+}
+
+// postDeploymentStatus posts a build status against the commit of the given
+// Bitbucket Deployment.
+func (e *Eventer) postDeploymentStatus(project, sha string, state deploymentStatusState) error {
+	u := fmt.Sprintf(
+		"https://api.bitbucket.org/2.0/repositories/%s/%s/commit/%s/statuses/build",
+		e.organisation, project, sha,
+	)
+
+	payload, err := json.Marshal(struct {
+		Key   string `json:"key"`
+		State string `json:"state"`
+	}{
+		Key:   "draughtsman-eventer",
+		State: string(state),
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	req, err := http.NewRequest("POST", u, bytes.NewBuffer(payload))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.request(req)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return microerror.Maskf(unexpectedStatusCode, fmt.Sprintf("received non-200 status code: %v", resp.StatusCode))
+	}
+
+	return nil
+}
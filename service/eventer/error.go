@@ -3,7 +3,10 @@ package eventer
 import (
 	"github.com/giantswarm/microerror"
 
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/bitbucket"
 	"github.com/giantswarm/draughtsman-eventer/service/eventer/github"
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/gitlab"
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/webhook"
 )
 
 var invalidConfigError = microerror.New("invalid config")
@@ -13,7 +16,14 @@ func IsInvalidConfig(err error) bool {
 	return microerror.Cause(err) == invalidConfigError
 }
 
-// IsNotFound asserts not found errors of eventer implementations.
+// IsNotFound asserts not found errors of eventer implementations, regardless
+// of which provider produced them.
 func IsNotFound(err error) bool {
-	return github.IsNotFound(err)
+	return github.IsNotFound(err) || gitlab.IsNotFound(err) || bitbucket.IsNotFound(err) || webhook.IsNotFound(err)
+}
+
+// IsCircuitOpen asserts that an eventer implementation's circuit breaker has
+// tripped, and it is failing fast rather than hammering its backend.
+func IsCircuitOpen(err error) bool {
+	return github.IsCircuitOpen(err)
 }
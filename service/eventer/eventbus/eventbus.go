@@ -0,0 +1,74 @@
+// Package eventbus provides a small deduplicating fan-in for
+// eventerspec.DeploymentEvents, so an Eventer fed by more than one source
+// (e.g. a poller and a webhook receiver running concurrently) does not
+// forward the same deployment twice.
+package eventbus
+
+import (
+	"sync"
+
+	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
+)
+
+// Bus fans multiple DeploymentEvent producers into a single channel,
+// dropping events whose ID has already been forwarded.
+type Bus struct {
+	mutex  sync.Mutex
+	seen   map[int]bool
+	closed bool
+
+	events chan eventerspec.DeploymentEvent
+}
+
+// New creates an empty Bus.
+func New() *Bus {
+	return &Bus{
+		seen:   map[int]bool{},
+		events: make(chan eventerspec.DeploymentEvent),
+	}
+}
+
+// Events returns the channel events published via Publish arrive on. It is
+// closed once Close is called.
+func (b *Bus) Events() <-chan eventerspec.DeploymentEvent {
+	return b.events
+}
+
+// Publish forwards event on Events, unless an event with the same ID has
+// already been published, or the Bus has been closed.
+func (b *Bus) Publish(event eventerspec.DeploymentEvent) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed || b.seen[event.ID] {
+		return
+	}
+	b.seen[event.ID] = true
+
+	b.events <- event
+}
+
+// Close closes Events, so a caller ranging over it returns instead of
+// blocking forever once every producer feeding this Bus has stopped. It is
+// safe to call more than once, and safe to call concurrently with Publish:
+// the closing send-holding-the-lock pattern here means Close cannot run
+// while a Publish is blocked sending, so Events is never closed out from
+// under an in-flight send.
+func (b *Bus) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	close(b.events)
+}
+
+// Forward republishes every event read from source on the Bus until source
+// is closed.
+func (b *Bus) Forward(source <-chan eventerspec.DeploymentEvent) {
+	for event := range source {
+		b.Publish(event)
+	}
+}
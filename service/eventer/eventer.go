@@ -0,0 +1,134 @@
+// Package eventer selects and configures the provider-specific Eventer
+// implementation (GitHub, GitLab or Bitbucket, each in its own sibling
+// package) named by Flag.Service.Eventer.Type, optionally wrapping it in a
+// webhook Eventer.
+package eventer
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/spf13/viper"
+
+	"github.com/giantswarm/draughtsman-eventer/flag"
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/bitbucket"
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/github"
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/gitlab"
+	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/webhook"
+	httpspec "github.com/giantswarm/draughtsman-eventer/service/http"
+)
+
+// Config represents the configuration used to create an Eventer. It selects
+// and configures one of the provider-specific implementations based on
+// Flag.Service.Eventer.Type.
+type Config struct {
+	// Dependencies.
+	HTTPClient httpspec.Client
+	Logger     micrologger.Logger
+
+	// Settings.
+	Flag  *flag.Flag
+	Viper *viper.Viper
+}
+
+// DefaultConfig provides a default configuration to create a new Eventer by
+// best effort.
+func DefaultConfig() Config {
+	return Config{
+		// Dependencies.
+		HTTPClient: nil,
+		Logger:     nil,
+
+		// Settings.
+		Flag:  nil,
+		Viper: nil,
+	}
+}
+
+// New creates a new configured Eventer, selecting the provider implementation
+// named by config.Flag.Service.Eventer.Type. If webhook ingestion is enabled,
+// the selected implementation becomes the Source of a webhook Eventer: it
+// serves FetchLatest and SetPendingStatus, and the events it polls are merged
+// with the events pushed in over the webhook receiver.
+func New(config Config) (eventerspec.Eventer, error) {
+	// Dependencies.
+	if config.HTTPClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.HTTPClient must not be empty")
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Logger must not be empty")
+	}
+
+	// Settings.
+	if config.Flag == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Flag must not be empty")
+	}
+	if config.Viper == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Viper must not be empty")
+	}
+
+	pollingEventer, err := newPollingEventer(config)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if !config.Viper.GetBool(config.Flag.Service.Eventer.Webhook.Enabled) {
+		return pollingEventer, nil
+	}
+
+	webhookConfig := webhook.DefaultConfig()
+
+	webhookConfig.Logger = config.Logger
+	webhookConfig.Source = pollingEventer
+
+	webhookConfig.ListenAddress = config.Viper.GetString(config.Flag.Service.Eventer.Webhook.ListenAddress)
+	webhookConfig.Secret = config.Viper.GetString(config.Flag.Service.Eventer.Webhook.Secret)
+
+	return webhook.New(webhookConfig)
+}
+
+// newPollingEventer creates the provider-specific Eventer named by
+// config.Flag.Service.Eventer.Type. It is used standalone, or as the Source
+// of a webhook Eventer when webhook ingestion is enabled.
+func newPollingEventer(config Config) (eventerspec.Eventer, error) {
+	eventerType := eventerspec.EventerType(config.Viper.GetString(config.Flag.Service.Eventer.Type))
+
+	switch eventerType {
+	case github.GithubEventerType, "":
+		githubConfig := github.DefaultConfig()
+
+		githubConfig.HTTPClient = config.HTTPClient
+		githubConfig.Logger = config.Logger
+
+		githubConfig.CircuitBreakerThreshold = config.Viper.GetInt(config.Flag.Service.Eventer.GitHub.CircuitBreakerThreshold)
+		githubConfig.OAuthToken = config.Viper.GetString(config.Flag.Service.Eventer.GitHub.OAuthToken)
+		githubConfig.Organisation = config.Viper.GetString(config.Flag.Service.Eventer.GitHub.Organisation)
+		githubConfig.PollInterval = config.Viper.GetDuration(config.Flag.Service.Eventer.GitHub.PollInterval)
+
+		return github.New(githubConfig)
+	case gitlab.GitLabEventerType:
+		gitlabConfig := gitlab.DefaultConfig()
+
+		gitlabConfig.HTTPClient = config.HTTPClient
+		gitlabConfig.Logger = config.Logger
+
+		gitlabConfig.BaseURL = config.Viper.GetString(config.Flag.Service.Eventer.GitLab.BaseURL)
+		gitlabConfig.OAuthToken = config.Viper.GetString(config.Flag.Service.Eventer.GitLab.OAuthToken)
+		gitlabConfig.PollInterval = config.Viper.GetDuration(config.Flag.Service.Eventer.GitLab.PollInterval)
+
+		return gitlab.New(gitlabConfig)
+	case bitbucket.BitbucketEventerType:
+		bitbucketConfig := bitbucket.DefaultConfig()
+
+		bitbucketConfig.HTTPClient = config.HTTPClient
+		bitbucketConfig.Logger = config.Logger
+
+		bitbucketConfig.OAuthToken = config.Viper.GetString(config.Flag.Service.Eventer.Bitbucket.OAuthToken)
+		bitbucketConfig.Organisation = config.Viper.GetString(config.Flag.Service.Eventer.Bitbucket.Organisation)
+		bitbucketConfig.PollInterval = config.Viper.GetDuration(config.Flag.Service.Eventer.Bitbucket.PollInterval)
+
+		return bitbucket.New(bitbucketConfig)
+	default:
+		return nil, microerror.Maskf(invalidConfigError, "unknown eventer type '%s'", eventerType)
+	}
+}
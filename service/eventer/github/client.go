@@ -2,14 +2,19 @@ package github
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
+	"github.com/cenk/backoff"
 	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/draughtsman-eventer/pkg/log"
 )
 
 const (
@@ -28,21 +33,115 @@ const (
 	DeploymentUrlFormat = "https://api.github.com/repos/%s/%s/deployments"
 )
 
-// request makes a request, handling any metrics and logging.
-func (e *Eventer) request(req *http.Request) (*http.Response, error) {
+// request makes a request, retrying 5xx and 429 responses with exponential
+// backoff and full jitter, and failing fast with circuitOpenError once the
+// circuit breaker has tripped. It also handles any metrics and logging.
+func (e *Eventer) request(ctx context.Context, req *http.Request) (*http.Response, error) {
+	logger := log.FromContext(ctx, e.logger)
+
+	if e.circuitOpen() {
+		return nil, microerror.Mask(circuitOpenError)
+	}
+
 	req.Header.Set("Authorization", fmt.Sprintf("token %s", e.oauthToken))
 
-	resp, err := e.client.Do(req)
-	if err != nil {
+	var resp *http.Response
+	o := func() error {
+		var err error
+
+		// Do drains req.Body, so a retried request needs a fresh reader or
+		// it resends an empty body. GetBody is only set for bodies net/http
+		// knows how to replay (e.g. the bytes.Buffer postDeploymentStatus
+		// builds); GET requests have no body and GetBody is nil.
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return microerror.Mask(err)
+			}
+			req.Body = body
+		}
+
+		resp, err = e.client.Do(req)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			wait := retryAfter(resp)
+			resp.Body.Close()
+			if wait > 0 {
+				time.Sleep(wait)
+			}
+			return microerror.Maskf(retryableStatusCodeError, "received status code %d", resp.StatusCode)
+		}
+
+		return nil
+	}
+
+	n := func(err error, d time.Duration) {
+		logger.Log("debug", "retrying github request", "message", err.Error(), "wait", d)
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.RandomizationFactor = 1 // full jitter
+	b.MaxElapsedTime = 2 * time.Minute
+
+	if err := backoff.RetryNotify(o, b, n); err != nil {
+		e.recordFailure()
 		return nil, microerror.Mask(err)
 	}
+	e.recordSuccess()
 
 	// Update rate limit metrics.
 	if err := updateRateLimitMetrics(resp); err != nil {
 		return nil, microerror.Mask(err)
 	}
 
-	return resp, err
+	return resp, nil
+}
+
+// retryAfter computes how long to wait before retrying resp, honoring the
+// Retry-After header, falling back to X-RateLimit-Reset.
+func retryAfter(resp *http.Response) time.Duration {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	if v := resp.Header.Get("X-RateLimit-Reset"); v != "" {
+		if epoch, err := strconv.ParseInt(v, 10, 64); err == nil {
+			wait := time.Until(time.Unix(epoch, 0))
+			if wait > 0 {
+				return wait
+			}
+		}
+	}
+
+	return 0
+}
+
+// circuitOpen reports whether the circuit breaker has tripped due to too
+// many consecutive request failures.
+func (e *Eventer) circuitOpen() bool {
+	e.circuitMutex.Lock()
+	defer e.circuitMutex.Unlock()
+
+	return e.consecutiveFailures >= e.circuitBreakerThreshold
+}
+
+func (e *Eventer) recordFailure() {
+	e.circuitMutex.Lock()
+	defer e.circuitMutex.Unlock()
+
+	e.consecutiveFailures++
+}
+
+func (e *Eventer) recordSuccess() {
+	e.circuitMutex.Lock()
+	defer e.circuitMutex.Unlock()
+
+	e.consecutiveFailures = 0
 }
 
 // filterDeploymentsWithoutStatuses filters out deployments that are finished -
@@ -65,7 +164,7 @@ func (e *Eventer) filterDeploymentsWithoutStatuses(deployments []deployment) []d
 
 // fetchNewDeploymentEvents fetches any new GitHub Deployment Events for the
 // given project.
-func (e *Eventer) fetchNewDeploymentEvents(project, environment string, etagMap map[string]string, filterStatuses bool) ([]deployment, error) {
+func (e *Eventer) fetchNewDeploymentEvents(ctx context.Context, project, environment string, etagMap map[string]string, filterStatuses bool) ([]deployment, error) {
 	var err error
 
 	var u *url.URL
@@ -93,13 +192,13 @@ func (e *Eventer) fetchNewDeploymentEvents(project, environment string, etagMap
 
 	startTime := time.Now()
 
-	resp, err := e.request(req)
+	resp, err := e.request(ctx, req)
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
 	defer resp.Body.Close()
 
-	updateDeploymentMetrics(e.organisation, project, resp.StatusCode, startTime)
+	updateDeploymentMetrics(e.organisation, project, environment, resp.StatusCode, startTime)
 
 	// Save the new etag header, so we don't get these deployment events again.
 	etagMap[project] = resp.Header.Get(etagHeader)
@@ -123,7 +222,7 @@ func (e *Eventer) fetchNewDeploymentEvents(project, environment string, etagMap
 	}
 
 	for index, deployment := range deployments {
-		deploymentStatuses, err := e.fetchDeploymentStatus(project, deployment)
+		deploymentStatuses, err := e.fetchDeploymentStatus(ctx, project, deployment)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
@@ -143,7 +242,7 @@ func (e *Eventer) fetchNewDeploymentEvents(project, environment string, etagMap
 }
 
 // fetchDeploymentStatus fetches Deployment Statuses for the given Deployment.
-func (e *Eventer) fetchDeploymentStatus(project string, deployment deployment) ([]deploymentStatus, error) {
+func (e *Eventer) fetchDeploymentStatus(ctx context.Context, project string, deployment deployment) ([]deploymentStatus, error) {
 	url := fmt.Sprintf(
 		deploymentStatusUrlFormat,
 		e.organisation,
@@ -158,7 +257,7 @@ func (e *Eventer) fetchDeploymentStatus(project string, deployment deployment) (
 
 	startTime := time.Now()
 
-	resp, err := e.request(req)
+	resp, err := e.request(ctx, req)
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
@@ -185,7 +284,10 @@ func (e *Eventer) fetchDeploymentStatus(project string, deployment deployment) (
 
 // postDeploymentStatus posts a Deployment Status for the given Deployment.
 func (e *Eventer) postDeploymentStatus(project string, id int, state deploymentStatusState) error {
-	e.logger.Log("debug", "posting deployment status", "project", project, "id", id, "state", state)
+	ctx := log.With(context.Background(), e.logger, "project", project, "id", id)
+	logger := log.FromContext(ctx, e.logger)
+
+	logger.Log("debug", "posting deployment status", "state", state)
 
 	url := fmt.Sprintf(
 		deploymentStatusUrlFormat,
@@ -210,7 +312,7 @@ func (e *Eventer) postDeploymentStatus(project string, id int, state deploymentS
 
 	startTime := time.Now()
 
-	resp, err := e.request(req)
+	resp, err := e.request(ctx, req)
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -1,8 +1,14 @@
 package github
 
 import (
+	"context"
+	"io/ioutil"
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
 )
 
 // TestFilterDeploymentsWithoutStatuses tests the
@@ -114,3 +120,66 @@ func TestFilterDeploymentsWithoutStatuses(t *testing.T) {
 		}
 	}
 }
+
+// recordingClient fails the given number of requests with a 500 before
+// succeeding, recording the body of every request it saw.
+type recordingClient struct {
+	failuresLeft int
+
+	bodies []string
+}
+
+func (c *recordingClient) Do(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.bodies = append(c.bodies, string(body))
+
+	if c.failuresLeft > 0 {
+		c.failuresLeft--
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       ioutil.NopCloser(nil),
+			Header:     http.Header{},
+		}, nil
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(nil),
+		Header:     http.Header{},
+	}, nil
+}
+
+// TestRequest_RetryResendsBody makes sure a retried request replays its
+// original body instead of the drained, now-empty one left behind by the
+// failed attempt.
+func TestRequest_RetryResendsBody(t *testing.T) {
+	client := &recordingClient{failuresLeft: 1}
+
+	e := Eventer{
+		client:                  client,
+		logger:                  microloggertest.New(),
+		circuitBreakerThreshold: defaultCircuitBreakerThreshold,
+		oauthToken:              "token",
+	}
+
+	req, err := http.NewRequest("POST", "https://api.github.com/repos/o/p/deployments/1/statuses", strings.NewReader(`{"state":"pending"}`))
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	if _, err := e.request(context.Background(), req); err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	if len(client.bodies) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(client.bodies))
+	}
+	for i, body := range client.bodies {
+		if body != `{"state":"pending"}` {
+			t.Fatalf("request %d: expected resent body, got %q", i, body)
+		}
+	}
+}
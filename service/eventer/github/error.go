@@ -11,6 +11,13 @@ func IsAlreadyExists(err error) bool {
 	return microerror.Cause(err) == alreadyExistsError
 }
 
+var circuitOpenError = microerror.New("circuit open")
+
+// IsCircuitOpen asserts circuitOpenError.
+func IsCircuitOpen(err error) bool {
+	return microerror.Cause(err) == circuitOpenError
+}
+
 var invalidConfigError = microerror.New("invalid config")
 
 // IsInvalidConfig asserts invalidConfigError.
@@ -25,6 +32,13 @@ func IsNotFound(err error) bool {
 	return microerror.Cause(err) == notFoundError
 }
 
+var retryableStatusCodeError = microerror.New("retryable status code")
+
+// IsRetryableStatusCode asserts retryableStatusCodeError.
+func IsRetryableStatusCode(err error) bool {
+	return microerror.Cause(err) == retryableStatusCodeError
+}
+
 var unexpectedStatusCode = microerror.New("unexpected status code")
 
 // IsUnexpectedStatusCode asserts unexpectedStatusCode.
@@ -1,15 +1,24 @@
 package github
 
 import (
+	"context"
+	"sync"
 	"time"
 
+	"github.com/cenk/backoff"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 
+	"github.com/giantswarm/draughtsman-eventer/pkg/log"
 	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
 	httpspec "github.com/giantswarm/draughtsman-eventer/service/http"
 )
 
+// defaultCircuitBreakerThreshold is the number of consecutive request
+// failures after which the circuit breaker trips, if Config.CircuitBreakerThreshold
+// is left unset.
+const defaultCircuitBreakerThreshold = 5
+
 var (
 	// GithubEventerType is an Eventer that uses Github Deployment Events as a backend.
 	GithubEventerType eventerspec.EventerType = "GithubEventer"
@@ -22,9 +31,13 @@ type Config struct {
 	Logger     micrologger.Logger
 
 	// Settings.
-	OAuthToken   string
-	Organisation string
-	PollInterval time.Duration
+	// CircuitBreakerThreshold is the number of consecutive request failures
+	// after which the circuit breaker trips and requests fail fast with
+	// circuitOpenError. Defaults to defaultCircuitBreakerThreshold if zero.
+	CircuitBreakerThreshold int
+	OAuthToken              string
+	Organisation            string
+	PollInterval            time.Duration
 }
 
 // DefaultConfig provides a default configuration to create a new GitHub
@@ -36,9 +49,10 @@ func DefaultConfig() Config {
 		Logger:     nil,
 
 		// Settings.
-		OAuthToken:   "",
-		Organisation: "",
-		PollInterval: 0,
+		CircuitBreakerThreshold: 0,
+		OAuthToken:              "",
+		Organisation:            "",
+		PollInterval:            0,
 	}
 }
 
@@ -52,10 +66,14 @@ type Eventer struct {
 	// Internals.
 	etagMap map[string]string
 
+	circuitMutex        sync.Mutex
+	consecutiveFailures int
+
 	// Settings.
-	oauthToken   string
-	organisation string
-	pollInterval time.Duration
+	circuitBreakerThreshold int
+	oauthToken              string
+	organisation            string
+	pollInterval            time.Duration
 }
 
 // New creates a new configured GitHub Eventer.
@@ -79,6 +97,11 @@ func New(config Config) (*Eventer, error) {
 		return nil, microerror.Maskf(invalidConfigError, "config.PollInterval must be greater than zero")
 	}
 
+	circuitBreakerThreshold := config.CircuitBreakerThreshold
+	if circuitBreakerThreshold == 0 {
+		circuitBreakerThreshold = defaultCircuitBreakerThreshold
+	}
+
 	eventer := &Eventer{
 		// Dependencies.
 		client: config.HTTPClient,
@@ -88,31 +111,41 @@ func New(config Config) (*Eventer, error) {
 		etagMap: map[string]string{},
 
 		// Settings.
-		oauthToken:   config.OAuthToken,
-		organisation: config.Organisation,
-		pollInterval: config.PollInterval,
+		circuitBreakerThreshold: circuitBreakerThreshold,
+		oauthToken:              config.OAuthToken,
+		organisation:            config.Organisation,
+		pollInterval:            config.PollInterval,
 	}
 
 	return eventer, nil
 }
 
-func (e *Eventer) FetchContinuously(projects []string, environment string) (<-chan eventerspec.DeploymentEvent, error) {
+func (e *Eventer) FetchContinuously(ctx context.Context, projects []string, environment string) (<-chan eventerspec.DeploymentEvent, error) {
 	e.logger.Log("debug", "starting polling for github deployment events", "interval", e.pollInterval)
 
 	deploymentEventChannel := make(chan eventerspec.DeploymentEvent)
 	ticker := time.NewTicker(e.pollInterval)
 
 	go func() {
+		defer ticker.Stop()
+		defer close(deploymentEventChannel)
+
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			case <-ticker.C:
 				for _, p := range projects {
-					d, err := e.fetchLatest(p, environment, true)
+					d, err := e.fetchLatest(ctx, p, environment, true)
 					if err != nil {
 						continue
 					}
 
-					deploymentEventChannel <- d
+					select {
+					case deploymentEventChannel <- d:
+					case <-ctx.Done():
+						return
+					}
 				}
 			}
 		}
@@ -122,7 +155,7 @@ func (e *Eventer) FetchContinuously(projects []string, environment string) (<-ch
 }
 
 func (e *Eventer) FetchLatest(project, environment string) (eventerspec.DeploymentEvent, error) {
-	d, err := e.fetchLatest(project, environment, false)
+	d, err := e.fetchLatest(context.Background(), project, environment, false)
 	if err != nil {
 		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
 	}
@@ -134,15 +167,18 @@ func (e *Eventer) SetPendingStatus(event eventerspec.DeploymentEvent) error {
 	return e.postDeploymentStatus(event.Name, event.ID, pendingState)
 }
 
-func (e *Eventer) fetchLatest(project, environment string, filterStatuses bool) (eventerspec.DeploymentEvent, error) {
-	e.logger.Log("debug", "fetching latest deployment", "project", project)
+func (e *Eventer) fetchLatest(ctx context.Context, project, environment string, filterStatuses bool) (eventerspec.DeploymentEvent, error) {
+	ctx = log.With(ctx, e.logger, "project", project)
+	logger := log.FromContext(ctx, e.logger)
+
+	logger.Log("debug", "fetching latest deployment")
 
-	deployments, err := e.fetchNewDeploymentEvents(project, environment, e.etagMap, filterStatuses)
+	deployments, err := e.fetchNewDeploymentEvents(ctx, project, environment, e.etagMap, filterStatuses)
 	if IsNotFound(err) {
-		e.logger.Log("debug", "no new deployment events", "project", project)
+		logger.Log("debug", "no new deployment events")
 		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
 	} else if err != nil {
-		e.logger.Log("error", "could not fetch deployment events", "message", err.Error())
+		logger.Log("error", "could not fetch deployment events", "message", err.Error())
 		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
 	}
 
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/giantswarm/draughtsman-eventer/service/metrics"
+)
+
+// updateRateLimitMetrics records GitHub's rate limit headers, if present, so
+// operators can alert before polling stalls.
+func updateRateLimitMetrics(resp *http.Response) error {
+	if v := resp.Header.Get("X-RateLimit-Remaining"); v != "" {
+		if remaining, err := strconv.ParseFloat(v, 64); err == nil {
+			metrics.GitHubRateLimitRemaining.Set(remaining)
+		}
+	}
+
+	if v := resp.Header.Get("X-RateLimit-Reset"); v != "" {
+		if reset, err := strconv.ParseFloat(v, 64); err == nil {
+			metrics.GitHubRateLimitReset.Set(reset)
+		}
+	}
+
+	return nil
+}
+
+// updateDeploymentMetrics records a completed request to fetch deployment
+// events for project in environment.
+func updateDeploymentMetrics(organisation, project, environment string, statusCode int, startTime time.Time) {
+	recordAPIRequest(statusCode, startTime)
+
+	metrics.DeploymentEventsFetchedTotal.WithLabelValues(project, environment, statusResultLabel(statusCode)).Inc()
+}
+
+// updateDeploymentStatusMetrics records a completed request to fetch or post
+// a deployment's status.
+func updateDeploymentStatusMetrics(method, organisation, project string, statusCode int, startTime time.Time) {
+	recordAPIRequest(statusCode, startTime)
+}
+
+func recordAPIRequest(statusCode int, startTime time.Time) {
+	metrics.GitHubAPIRequestsTotal.WithLabelValues(strconv.Itoa(statusCode)).Inc()
+	metrics.GitHubAPIRequestDuration.Observe(time.Since(startTime).Seconds())
+}
+
+func statusResultLabel(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return "error"
+	}
+
+	return "success"
+}
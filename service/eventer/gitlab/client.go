@@ -0,0 +1,161 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	// deploymentsUrlFormat is the string format for the GitLab API call
+	// listing Deployments for a project.
+	// See: https://docs.gitlab.com/ee/api/deployments.html#list-project-deployments
+	deploymentsUrlFormat = "%s/api/v4/projects/%s/deployments"
+
+	// etagHeader is the header used for etag.
+	// See: https://en.wikipedia.org/wiki/HTTP_ETag.
+	etagHeader = "Etag"
+)
+
+// deployment represents a GitLab deployment.
+type deployment struct {
+	ID          int    `json:"id"`
+	Ref         string `json:"ref"`
+	Environment string `json:"environment"`
+	Status      string `json:"status"`
+}
+
+// deploymentStatusState represents the state of a Deployment Status.
+type deploymentStatusState string
+
+const (
+	pendingState deploymentStatusState = "running"
+	successState deploymentStatusState = "success"
+	failureState deploymentStatusState = "failed"
+)
+
+func (d deployment) isPending() bool {
+	return d.Status == "" || deploymentStatusState(d.Status) == pendingState
+}
+
+// request makes a request against the GitLab API, authenticating with the
+// configured personal access token.
+func (e *Eventer) request(req *http.Request) (*http.Response, error) {
+	req.Header.Set("PRIVATE-TOKEN", e.oauthToken)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return resp, nil
+}
+
+// fetchNewDeploymentEvents fetches any new GitLab Deployments for the given
+// project, using the given etagMap to avoid refetching unchanged results.
+func (e *Eventer) fetchNewDeploymentEvents(project, environment string, etagMap map[string]string, filterPending bool) ([]deployment, error) {
+	var err error
+
+	var u *url.URL
+	{
+		u, err = url.Parse(fmt.Sprintf(deploymentsUrlFormat, e.baseURL, url.PathEscape(project)))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		q := u.Query()
+		q.Set("environment", environment)
+		q.Set("order_by", "id")
+		q.Set("sort", "desc")
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// If we have an etag header for this project, then we have already
+	// requested deployments for it, so only ask for changes.
+	if val, ok := etagMap[project]; ok {
+		req.Header.Set("If-None-Match", val)
+	}
+
+	resp, err := e.request(req)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	defer resp.Body.Close()
+
+	etagMap[project] = resp.Header.Get(etagHeader)
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, microerror.Mask(notFoundError)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, microerror.Maskf(unexpectedStatusCode, fmt.Sprintf("received non-200 status code: %v", resp.StatusCode))
+	}
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var deployments []deployment
+	if err := json.Unmarshal(bytes, &deployments); err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	if filterPending {
+		filtered := []deployment{}
+		for _, d := range deployments {
+			if d.isPending() {
+				filtered = append(filtered, d)
+			}
+		}
+		deployments = filtered
+	}
+
+	if len(deployments) == 0 {
+		return nil, microerror.Mask(notFoundError)
+	}
+
+	return deployments, nil
+}
+
+// postDeploymentStatus updates the status of the given GitLab Deployment.
+func (e *Eventer) postDeploymentStatus(project string, id int, state deploymentStatusState) error {
+	u := fmt.Sprintf(deploymentsUrlFormat+"/%v", e.baseURL, url.PathEscape(project), id)
+
+	payload, err := json.Marshal(struct {
+		Status string `json:"status"`
+	}{
+		Status: string(state),
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	req, err := http.NewRequest("PUT", u, bytes.NewBuffer(payload))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.request(req)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return microerror.Maskf(unexpectedStatusCode, fmt.Sprintf("received non-200 status code: %v", resp.StatusCode))
+	}
+
+	return nil
+}
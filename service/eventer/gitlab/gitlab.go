@@ -0,0 +1,168 @@
+package gitlab
+
+import (
+	"context"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+
+	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
+	httpspec "github.com/giantswarm/draughtsman-eventer/service/http"
+)
+
+var (
+	// GitLabEventerType is an Eventer that uses GitLab Deployments as a backend.
+	GitLabEventerType eventerspec.EventerType = "GitLabEventer"
+)
+
+// Config represents the configuration used to create a GitLab Eventer.
+type Config struct {
+	// Dependencies.
+	HTTPClient httpspec.Client
+	Logger     micrologger.Logger
+
+	// Settings.
+	// BaseURL is the base URL of the GitLab instance, e.g.
+	// https://gitlab.com, without a trailing slash.
+	BaseURL      string
+	OAuthToken   string
+	PollInterval time.Duration
+}
+
+// DefaultConfig provides a default configuration to create a new GitLab
+// Eventer by best effort.
+func DefaultConfig() Config {
+	return Config{
+		// Dependencies.
+		HTTPClient: nil,
+		Logger:     nil,
+
+		// Settings.
+		BaseURL:      "",
+		OAuthToken:   "",
+		PollInterval: 0,
+	}
+}
+
+// Eventer is an implementation of the Eventer interface, that uses GitLab
+// Deployments as a backend.
+type Eventer struct {
+	// Dependencies.
+	client httpspec.Client
+	logger micrologger.Logger
+
+	// Internals.
+	etagMap map[string]string
+
+	// Settings.
+	baseURL      string
+	oauthToken   string
+	pollInterval time.Duration
+}
+
+// New creates a new configured GitLab Eventer.
+func New(config Config) (*Eventer, error) {
+	// Dependencies.
+	if config.HTTPClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.HTTPClient must not be empty")
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Logger must not be empty")
+	}
+
+	// Settings.
+	if config.BaseURL == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.BaseURL must not be empty")
+	}
+	if config.OAuthToken == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.OAuthToken must not be empty")
+	}
+	if config.PollInterval.Seconds() == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "config.PollInterval must be greater than zero")
+	}
+
+	eventer := &Eventer{
+		// Dependencies.
+		client: config.HTTPClient,
+		logger: config.Logger,
+
+		// Internals.
+		etagMap: map[string]string{},
+
+		// Settings.
+		baseURL:      config.BaseURL,
+		oauthToken:   config.OAuthToken,
+		pollInterval: config.PollInterval,
+	}
+
+	return eventer, nil
+}
+
+func (e *Eventer) FetchContinuously(ctx context.Context, projects []string, environment string) (<-chan eventerspec.DeploymentEvent, error) {
+	e.logger.Log("debug", "starting polling for gitlab deployment events", "interval", e.pollInterval)
+
+	deploymentEventChannel := make(chan eventerspec.DeploymentEvent)
+	ticker := time.NewTicker(e.pollInterval)
+
+	go func() {
+		defer ticker.Stop()
+		defer close(deploymentEventChannel)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				for _, p := range projects {
+					d, err := e.fetchLatest(p, environment, true)
+					if err != nil {
+						continue
+					}
+
+					select {
+					case deploymentEventChannel <- d:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return deploymentEventChannel, nil
+}
+
+func (e *Eventer) FetchLatest(project, environment string) (eventerspec.DeploymentEvent, error) {
+	d, err := e.fetchLatest(project, environment, false)
+	if err != nil {
+		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
+	}
+
+	return d, nil
+}
+
+func (e *Eventer) SetPendingStatus(event eventerspec.DeploymentEvent) error {
+	return e.postDeploymentStatus(event.Name, event.ID, pendingState)
+}
+
+func (e *Eventer) fetchLatest(project, environment string, filterPending bool) (eventerspec.DeploymentEvent, error) {
+	e.logger.Log("debug", "fetching latest deployment", "project", project)
+
+	deployments, err := e.fetchNewDeploymentEvents(project, environment, e.etagMap, filterPending)
+	if IsNotFound(err) {
+		e.logger.Log("debug", "no new deployment events", "project", project)
+		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
+	} else if err != nil {
+		e.logger.Log("error", "could not fetch deployment events", "message", err.Error())
+		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
+	}
+
+	d := deployments[0]
+
+	return eventerspec.DeploymentEvent{
+		ID:   d.ID,
+		Name: project,
+		Sha:  d.Ref,
+	}, nil
+}
@@ -1,5 +1,7 @@
 package spec
 
+import "context"
+
 // EventerType represents the type of Eventer to configure.
 type EventerType string
 
@@ -7,11 +9,16 @@ type EventerType string
 type Eventer interface {
 	// FetchContinuously returns a channel of DeploymentEvents. This channel can
 	// be ranged over to receive DeploymentEvents as they come in. In case of an
-	// error during setup, the error will be non-nil.
-	FetchContinuously(projects []string, environment string) (<-chan DeploymentEvent, error)
+	// error during setup, the error will be non-nil. The returned channel is
+	// closed once ctx is cancelled, so callers can stop consuming it instead of
+	// leaking a goroutine across e.g. a leader election hand-off.
+	FetchContinuously(ctx context.Context, projects []string, environment string) (<-chan DeploymentEvent, error)
 	// FetchLatest returns the latest DeploymentEvent for the given project in the
 	// given environment.
 	FetchLatest(project, environment string) (DeploymentEvent, error)
+	// SetPendingStatus marks the given DeploymentEvent as pending against the
+	// backend the Eventer implementation talks to.
+	SetPendingStatus(event DeploymentEvent) error
 }
 
 // DeploymentEvent represents a request for a chart to be deployed.
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var invalidConfigError = microerror.New("invalid config")
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var invalidSignatureError = microerror.New("invalid signature")
+
+// IsInvalidSignature asserts invalidSignatureError.
+func IsInvalidSignature(err error) bool {
+	return microerror.Cause(err) == invalidSignatureError
+}
+
+var notFoundError = microerror.New("not found")
+
+// IsNotFound asserts notFoundError.
+func IsNotFound(err error) bool {
+	return microerror.Cause(err) == notFoundError
+}
+
+var unsupportedPayloadError = microerror.New("unsupported payload")
+
+// IsUnsupportedPayload asserts unsupportedPayloadError.
+func IsUnsupportedPayload(err error) bool {
+	return microerror.Cause(err) == unsupportedPayloadError
+}
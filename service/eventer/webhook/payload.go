@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+
+	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
+)
+
+// githubSignatureHeader is the header GitHub signs deployment payloads with.
+// See: https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries
+const githubSignatureHeader = "X-Hub-Signature-256"
+
+// gitlabTokenHeader is the header GitLab sends the configured secret token
+// in. GitLab does not HMAC-sign payloads, it echoes back the shared secret.
+// See: https://docs.gitlab.com/ee/user/project/integrations/webhooks.html
+const gitlabTokenHeader = "X-Gitlab-Token"
+
+// verifyGithubSignature verifies the HMAC-SHA256 signature GitHub attaches to
+// deployment webhook deliveries.
+func verifyGithubSignature(secret string, body []byte, signature string) error {
+	const prefix = "sha256="
+	if !strings.HasPrefix(signature, prefix) {
+		return microerror.Mask(invalidSignatureError)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(strings.TrimPrefix(signature, prefix)), []byte(expected)) {
+		return microerror.Mask(invalidSignatureError)
+	}
+
+	return nil
+}
+
+// verifyGitlabToken verifies the shared secret token GitLab sends alongside
+// webhook deliveries.
+func verifyGitlabToken(secret, token string) error {
+	if !hmac.Equal([]byte(token), []byte(secret)) {
+		return microerror.Mask(invalidSignatureError)
+	}
+
+	return nil
+}
+
+// githubDeploymentPayload is the subset of GitHub's `deployment` webhook
+// event payload we care about.
+type githubDeploymentPayload struct {
+	Action     string `json:"action"`
+	Deployment struct {
+		ID  int    `json:"id"`
+		Sha string `json:"sha"`
+	} `json:"deployment"`
+	Repository struct {
+		Name string `json:"name"`
+	} `json:"repository"`
+}
+
+// gitlabDeploymentPayload is the subset of GitLab's `deployment` webhook
+// event payload we care about.
+type gitlabDeploymentPayload struct {
+	ObjectKind   string `json:"object_kind"`
+	Status       string `json:"status"`
+	DeploymentID int    `json:"deployment_id"`
+	DeployableID int    `json:"deployable_id"`
+	ShortSha     string `json:"short_sha"`
+	Project      struct {
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+// parseGithubDeploymentEvent parses a GitHub `deployment` webhook payload
+// into a DeploymentEvent.
+func parseGithubDeploymentEvent(body []byte) (eventerspec.DeploymentEvent, error) {
+	var payload githubDeploymentPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
+	}
+
+	if payload.Repository.Name == "" || payload.Deployment.Sha == "" {
+		return eventerspec.DeploymentEvent{}, microerror.Mask(unsupportedPayloadError)
+	}
+
+	return eventerspec.DeploymentEvent{
+		ID:   payload.Deployment.ID,
+		Name: payload.Repository.Name,
+		Sha:  payload.Deployment.Sha,
+	}, nil
+}
+
+// parseGitlabDeploymentEvent parses a GitLab `deployment` webhook payload
+// into a DeploymentEvent.
+func parseGitlabDeploymentEvent(body []byte) (eventerspec.DeploymentEvent, error) {
+	var payload gitlabDeploymentPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return eventerspec.DeploymentEvent{}, microerror.Mask(err)
+	}
+
+	if payload.ObjectKind != "deployment" || payload.Project.Name == "" || payload.ShortSha == "" {
+		return eventerspec.DeploymentEvent{}, microerror.Mask(unsupportedPayloadError)
+	}
+
+	return eventerspec.DeploymentEvent{
+		ID:   payload.DeploymentID,
+		Name: payload.Project.Name,
+		Sha:  payload.ShortSha,
+	}, nil
+}
@@ -0,0 +1,239 @@
+package webhook
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/eventbus"
+	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
+)
+
+var (
+	// WebhookEventerType is an Eventer that receives deployment events pushed
+	// by GitHub/GitLab webhooks, optionally falling back to a Source Eventer
+	// for FetchLatest/SetPendingStatus so it can run in "hybrid" mode.
+	WebhookEventerType eventerspec.EventerType = "WebhookEventer"
+)
+
+// Config represents the configuration used to create a webhook Eventer.
+type Config struct {
+	// Dependencies.
+	Logger micrologger.Logger
+	// Source is used to serve FetchLatest/SetPendingStatus, and the events of
+	// its own FetchContinuously are merged with the events received via
+	// webhook. It may be left nil, in
+	// which case FetchLatest always returns a not found error and
+	// SetPendingStatus is a no-op.
+	Source eventerspec.Eventer
+
+	// Settings.
+	ListenAddress string
+	Secret        string
+}
+
+// DefaultConfig provides a default configuration to create a new webhook
+// Eventer by best effort.
+func DefaultConfig() Config {
+	return Config{
+		// Dependencies.
+		Logger: nil,
+		Source: nil,
+
+		// Settings.
+		ListenAddress: "",
+		Secret:        "",
+	}
+}
+
+// Eventer is an implementation of the Eventer interface that receives
+// GitHub/GitLab `deployment` webhooks, alongside whatever polling its Source
+// does, if one is configured.
+type Eventer struct {
+	// Dependencies.
+	logger micrologger.Logger
+	source eventerspec.Eventer
+
+	// Settings.
+	listenAddress string
+	secret        string
+}
+
+// New creates a new configured webhook Eventer.
+func New(config Config) (*Eventer, error) {
+	// Dependencies.
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Logger must not be empty")
+	}
+
+	// Settings.
+	if config.ListenAddress == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.ListenAddress must not be empty")
+	}
+	if config.Secret == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.Secret must not be empty")
+	}
+
+	eventer := &Eventer{
+		// Dependencies.
+		logger: config.Logger,
+		source: config.Source,
+
+		// Settings.
+		listenAddress: config.ListenAddress,
+		secret:        config.Secret,
+	}
+
+	return eventer, nil
+}
+
+// FetchContinuously starts an HTTP server listening for GitHub/GitLab
+// deployment webhooks and returns a channel fed a DeploymentEvent for every
+// verified delivery received for one of the given projects. If a Source is
+// configured, its own FetchContinuously is also forwarded onto the same
+// channel, so polling and webhook ingestion can run side by side without the
+// informer seeing the same deployment twice.
+func (e *Eventer) FetchContinuously(ctx context.Context, projects []string, environment string) (<-chan eventerspec.DeploymentEvent, error) {
+	wanted := map[string]bool{}
+	for _, p := range projects {
+		wanted[p] = true
+	}
+
+	bus := eventbus.New()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/webhooks/github", e.githubHandler(wanted, bus))
+	mux.HandleFunc("/webhooks/gitlab", e.gitlabHandler(wanted, bus))
+
+	// Start the source first, so a failure here returns before anything is
+	// bound, instead of leaking a listener this function would otherwise
+	// never get a chance to close.
+	if e.source != nil {
+		sourceEventChannel, err := e.source.FetchContinuously(ctx, projects, environment)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		go bus.Forward(sourceEventChannel)
+	}
+
+	server := &http.Server{
+		Addr:    e.listenAddress,
+		Handler: mux,
+	}
+
+	// Listen synchronously, so a bind failure (e.g. the previous boot cycle's
+	// listener not having released the address yet after a quick leader
+	// election lease flap) is returned to the caller instead of only logged
+	// from the goroutine below.
+	listener, err := net.Listen("tcp", e.listenAddress)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	e.logger.Log("debug", "starting webhook receiver", "address", e.listenAddress)
+
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			e.logger.Log("error", "webhook receiver stopped", "message", err.Error())
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.Background()); err != nil {
+			e.logger.Log("error", "could not shut down webhook receiver", "message", err.Error())
+		}
+		bus.Close()
+	}()
+
+	return bus.Events(), nil
+}
+
+// FetchLatest delegates to the configured Source Eventer, if any, so hybrid
+// mode can still bootstrap from the REST API on startup.
+func (e *Eventer) FetchLatest(project, environment string) (eventerspec.DeploymentEvent, error) {
+	if e.source == nil {
+		return eventerspec.DeploymentEvent{}, microerror.Mask(notFoundError)
+	}
+
+	return e.source.FetchLatest(project, environment)
+}
+
+// SetPendingStatus delegates to the configured Source Eventer, if any.
+func (e *Eventer) SetPendingStatus(event eventerspec.DeploymentEvent) error {
+	if e.source == nil {
+		return nil
+	}
+
+	return e.source.SetPendingStatus(event)
+}
+
+func (e *Eventer) githubHandler(wanted map[string]bool, bus *eventbus.Bus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if err := verifyGithubSignature(e.secret, body, r.Header.Get(githubSignatureHeader)); err != nil {
+			e.logger.Log("warning", "rejected github webhook with invalid signature")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		event, err := parseGithubDeploymentEvent(body)
+		if IsUnsupportedPayload(err) {
+			w.WriteHeader(http.StatusOK)
+			return
+		} else if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		e.dispatch(wanted, bus, event)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (e *Eventer) gitlabHandler(wanted map[string]bool, bus *eventbus.Bus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := verifyGitlabToken(e.secret, r.Header.Get(gitlabTokenHeader)); err != nil {
+			e.logger.Log("warning", "rejected gitlab webhook with invalid token")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		event, err := parseGitlabDeploymentEvent(body)
+		if IsUnsupportedPayload(err) {
+			w.WriteHeader(http.StatusOK)
+			return
+		} else if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		e.dispatch(wanted, bus, event)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (e *Eventer) dispatch(wanted map[string]bool, bus *eventbus.Bus, event eventerspec.DeploymentEvent) {
+	if !wanted[event.Name] {
+		return
+	}
+
+	e.logger.Log("debug", "received deployment event via webhook", "project", event.Name)
+	bus.Publish(event)
+}
@@ -0,0 +1,114 @@
+// Package healthz reports whether the Kubernetes API is reachable and
+// whether this replica currently holds the leader election lease, so it can
+// be scraped by a /healthz endpoint.
+package healthz
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Config represents the configuration used to create a healthz Service.
+type Config struct {
+	// Dependencies.
+	K8sClient kubernetes.Interface
+	Logger    micrologger.Logger
+}
+
+// DefaultConfig provides a default configuration to create a new healthz
+// Service by best effort.
+func DefaultConfig() Config {
+	return Config{
+		// Dependencies.
+		K8sClient: nil,
+		Logger:    nil,
+	}
+}
+
+// Response is the JSON body served by Service.Handler.
+type Response struct {
+	// OK reports whether the Kubernetes API responded to a version check.
+	OK bool `json:"ok"`
+	// Leader reports whether this replica currently holds the leader
+	// election lease. It is always true when leader election is disabled,
+	// since there is only ever one active replica in that case.
+	Leader bool `json:"leader"`
+}
+
+// Service implements a healthz check, reporting whether the Kubernetes API is
+// reachable and whether this replica currently holds the leader election
+// lease.
+type Service struct {
+	// Dependencies.
+	k8sClient kubernetes.Interface
+	logger    micrologger.Logger
+
+	// Internals.
+	mutex  sync.RWMutex
+	leader bool
+}
+
+// New creates a new configured healthz Service. Leader defaults to true, so
+// deployments that don't enable leader election report healthy out of the
+// box.
+func New(config Config) (*Service, error) {
+	// Dependencies.
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.K8sClient must not be empty")
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Logger must not be empty")
+	}
+
+	s := &Service{
+		// Dependencies.
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+
+		// Internals.
+		leader: true,
+	}
+
+	return s, nil
+}
+
+// SetLeader records whether this replica currently holds the leader election
+// lease, so the next request served by Handler reflects it. It is safe to
+// call concurrently with Handler.
+func (s *Service) SetLeader(leader bool) {
+	s.mutex.Lock()
+	s.leader = leader
+	s.mutex.Unlock()
+}
+
+// Handler returns the HTTP handler serving this Service's Response, to be
+// mounted at "/healthz".
+func (s *Service) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, err := s.k8sClient.Discovery().ServerVersion()
+		ok := err == nil
+		if !ok {
+			s.logger.Log("error", fmt.Sprintf("healthz check against kubernetes api failed: %#v", microerror.Mask(err)))
+		}
+
+		s.mutex.RLock()
+		leader := s.leader
+		s.mutex.RUnlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if !ok {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		json.NewEncoder(w).Encode(Response{
+			OK:     ok,
+			Leader: leader,
+		})
+	}
+}
@@ -1,22 +1,27 @@
 package informer
 
 import (
+	"context"
 	"fmt"
 	"strconv"
-	"sync"
 	"time"
 
 	"github.com/cenk/backoff"
-	"github.com/giantswarm/draughtsmantpr"
-	draughtsmantprspec "github.com/giantswarm/draughtsmantpr/spec"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/giantswarm/draughtsman-eventer/pkg/log"
 	"github.com/giantswarm/draughtsman-eventer/service/eventer"
 	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
+	"github.com/giantswarm/draughtsman-eventer/service/metrics"
 	"github.com/giantswarm/draughtsman-eventer/service/tpo"
 )
 
+// projectsFieldPath is the path, within the TPO's unstructured object, of
+// the slice of tracked projects.
+var projectsFieldPath = []string{"spec", "projects"}
+
 // Config represents the configuration used to create a informer service.
 type Config struct {
 	// Dependencies.
@@ -57,7 +62,17 @@ type Service struct {
 	tpo      tpo.Controller
 
 	// Internals.
-	bootOnce sync.Once
+	// subscriptionCtx and deploymentEventChannel cache the deployment event
+	// subscription established for the currently running Boot ctx, so a
+	// bootWithError retried by backoff (e.g. after an alignEventWithObject
+	// failure) resumes consuming the existing channel instead of calling
+	// tpo.Watch/eventer.FetchContinuously again. Those calls start resources
+	// (a k8s watch connection, the webhook eventer's HTTP listener and any
+	// Source it wraps) that are only torn down when ctx is cancelled, so
+	// calling them again while ctx is still live would leak the previous
+	// attempt's resources alongside the new ones.
+	subscriptionCtx        context.Context
+	deploymentEventChannel <-chan eventerspec.DeploymentEvent
 
 	// Settings.
 	environment string
@@ -99,9 +114,6 @@ func New(config Config) (*Service, error) {
 		logger:   config.Logger,
 		tpo:      config.TPO,
 
-		// Internals
-		bootOnce: sync.Once{},
-
 		// Settings.
 		environment: config.Environment,
 		projects:    config.Projects,
@@ -110,48 +122,46 @@ func New(config Config) (*Service, error) {
 	return newInformer, nil
 }
 
-func (s *Service) Boot() {
-	s.bootOnce.Do(func() {
-		o := func() error {
-			err := s.bootWithError()
-			if err != nil {
-				return microerror.Mask(err)
-			}
-
-			return nil
+// Boot bootstraps the informer and then blocks, realigning the TPO with
+// incoming deployment events until ctx is cancelled. On cancellation it
+// returns instead of retrying, so a leader election hand-off stops this
+// informer cleanly rather than leaving it running alongside the new leader's.
+// Boot is safe to call again with a fresh ctx afterwards, e.g. if this
+// replica later re-acquires a lost leader election lease.
+func (s *Service) Boot(ctx context.Context) {
+	o := func() error {
+		err := s.bootWithError(ctx)
+		if err != nil {
+			return microerror.Mask(err)
 		}
 
-		n := func(err error, d time.Duration) {
-			s.logger.Log("warning", fmt.Sprintf("retrying informer boot due to error: %#v", microerror.Mask(err)))
-		}
+		return nil
+	}
 
-		err := backoff.RetryNotify(o, s.backOff, n)
-		if err != nil {
-			s.logger.Log("error", fmt.Sprintf("stop informer boot retries due to too many errors: %#v", microerror.Mask(err)))
-			s.exitFunc(1)
-		}
-	})
-}
+	n := func(err error, d time.Duration) {
+		metrics.InformerBootRetriesTotal.Inc()
+		s.logger.Log("warning", fmt.Sprintf("retrying informer boot due to error: %#v", microerror.Mask(err)))
+	}
 
-func (s *Service) bootWithError() error {
-	var err error
+	err := backoff.RetryNotify(o, s.backOff, n)
+	if err != nil && ctx.Err() != nil {
+		// ctx was cancelled while bootWithError was running, e.g. because
+		// leadership was lost. That is an expected shutdown, not a boot
+		// failure, so don't retry and don't exit the process over it.
+		return
+	}
+	if err != nil {
+		s.logger.Log("error", fmt.Sprintf("stop informer boot retries due to too many errors: %#v", microerror.Mask(err)))
+		s.exitFunc(1)
+	}
+}
 
-	// Get TPO to make sure it exists and to have the object which we use to
-	// further update with deployment event information.
-	var TPO *draughtsmantpr.CustomObject
-	{
-		TPO, err = s.tpo.Get()
-		if tpo.IsNotFound(err) {
-			// In case the TPO does not yet exist we are going to initialize it below.
-			// Then we simply fall through here.
-		} else if err != nil {
-			return microerror.Mask(err)
-		}
-		// In case the TPO is for whatever reason nil, we initialize the structure
-		// with a new pointer to be able to setup properly below.
-		if TPO == nil {
-			TPO = &draughtsmantpr.CustomObject{}
-		}
+func (s *Service) bootWithError(ctx context.Context) error {
+	// Make sure the TPO can be read before anything else is done. A TPO that
+	// does not exist yet is fine, alignEventWithObject creates it.
+	_, err := s.tpo.Get()
+	if err != nil && !tpo.IsNotFound(err) {
+		return microerror.Mask(err)
 	}
 
 	// If the TPO was not found the project list is empty, which means we
@@ -164,60 +174,122 @@ func (s *Service) bootWithError() error {
 			// will get fixed later as soon as there is a deployment event. Then the
 			// eventer updates the TPO and the operator can do the magic.
 			continue
+		} else if eventer.IsCircuitOpen(err) {
+			// The eventer's circuit breaker has tripped, so its backend is
+			// currently failing too often to be worth hammering further. Skip
+			// this project for now instead of escalating to a boot retry; the
+			// circuit closes again once the backend recovers.
+			s.logger.Log("warning", "eventer circuit open, skipping project for now", "project", p)
+			continue
 		} else if err != nil {
 			return microerror.Mask(err)
 		}
 
-		err = s.alignEventWithObject(e, TPO)
+		err = s.alignEventWithObject(e)
 		if err != nil {
 			return microerror.Mask(err)
 		}
 	}
 
-	// From here on we watch for new deployment events and update the TPO
-	// accordingly.
-	{
-		deploymentEventChannel, err := s.eventer.FetchContinuously(s.projects, s.environment)
+	// Subscribe to the TPO watch and deployment event stream at most once
+	// per ctx lifetime: if this is a fresh ctx (a new Boot call, e.g. after
+	// re-acquiring a lost leader election lease) subscribe now, otherwise
+	// reuse the channels from the attempt that established them, so a
+	// backoff retry below doesn't start a second k8s watch connection or a
+	// second webhook listener/Source poller alongside the still-running
+	// first one.
+	if s.subscriptionCtx != ctx {
+		// Watch the TPO for edits made by something other than this
+		// process, so operators editing it directly (or a second replica)
+		// are noticed rather than silently clobbered on our next Ensure.
+		tpoChannel, err := s.tpo.Watch(ctx)
 		if err != nil {
 			return microerror.Mask(err)
 		}
 
-		for e := range deploymentEventChannel {
-			TPO, err := s.tpo.Get()
-			if err != nil {
-				return microerror.Mask(err)
+		go func() {
+			for range tpoChannel {
+				s.logger.Log("debug", "observed external TPO change")
 			}
+		}()
 
-			err = s.alignEventWithObject(e, TPO)
-			if err != nil {
-				return microerror.Mask(err)
-			}
+		// From here on we watch for new deployment events and update the
+		// TPO accordingly.
+		deploymentEventChannel, err := s.eventer.FetchContinuously(ctx, s.projects, s.environment)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		s.subscriptionCtx = ctx
+		s.deploymentEventChannel = deploymentEventChannel
+	}
+
+	for e := range s.deploymentEventChannel {
+		err := s.alignEventWithObject(e)
+		if err != nil {
+			return microerror.Mask(err)
 		}
 	}
 
 	return nil
 }
 
-func (s *Service) alignEventWithObject(e eventerspec.DeploymentEvent, TPO *draughtsmantpr.CustomObject) error {
-	newProject := draughtsmantprspec.Project{
-		ID:   strconv.Itoa(e.ID),
-		Name: e.Name,
-		Ref:  e.Sha,
-	}
+// alignEventWithObject records e in the TPO's spec.projects slice. It
+// fetches the current TPO itself, rather than taking one as an argument, so
+// it can retry the whole Get -> mutate -> Ensure cycle against a fresh copy
+// when Ensure reports IsConflict, instead of losing the update to whoever
+// won the race.
+func (s *Service) alignEventWithObject(e eventerspec.DeploymentEvent) error {
+	ctx := log.With(context.Background(), s.logger, "project", e.Name, "deploymentID", e.ID)
+	logger := log.FromContext(ctx, s.logger)
 
-	var updated bool
-	TPO.Spec.Projects, updated = ensureProject(TPO.Spec.Projects, newProject)
-	if !updated {
-		return nil
-	}
-	s.logger.Log("debug", "found new deployment", "project", newProject.Name)
+	newProject := newProjectObject(strconv.Itoa(e.ID), e.Name, e.Sha)
 
-	err := s.tpo.Ensure(TPO)
-	if err != nil {
-		return microerror.Mask(err)
+	const maxConflictRetries = 3
+	for attempt := 0; ; attempt++ {
+		TPO, err := s.tpo.Get()
+		if tpo.IsNotFound(err) {
+			TPO = &unstructured.Unstructured{Object: map[string]interface{}{}}
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		projects, _, err := unstructured.NestedSlice(TPO.Object, projectsFieldPath...)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		var updated bool
+		projects, updated = ensureProject(projects, newProject)
+		if !updated {
+			return nil
+		}
+		logger.Log("debug", "found new deployment")
+
+		if err := unstructured.SetNestedSlice(TPO.Object, projects, projectsFieldPath...); err != nil {
+			return microerror.Mask(err)
+		}
+
+		err = s.tpo.Ensure(TPO)
+		if tpo.IsConflict(err) {
+			if attempt >= maxConflictRetries {
+				metrics.TPOUpdatesTotal.WithLabelValues(e.Name, "error").Inc()
+				return microerror.Mask(err)
+			}
+			logger.Log("warning", "TPO changed concurrently, retrying")
+			continue
+		} else if err != nil {
+			metrics.TPOUpdatesTotal.WithLabelValues(e.Name, "error").Inc()
+			return microerror.Mask(err)
+		}
+
+		break
 	}
 
-	err = s.eventer.SetPendingStatus(e)
+	metrics.TPOUpdatesTotal.WithLabelValues(e.Name, "success").Inc()
+	metrics.InformerLastEventTimestamp.WithLabelValues(e.Name).Set(float64(time.Now().Unix()))
+
+	err := s.eventer.SetPendingStatus(e)
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -235,23 +307,44 @@ func containsEmptyItems(projects []string) bool {
 	return false
 }
 
-// ensureProject takes care of updating the given projects list with the given
-// project. In case the project cannot be found in the list, it is added. In
-// case the project is found in the list, it is updated, if it changed. In case
-// the list got updated somehow the returned boolean is true.
-func ensureProject(projects []draughtsmantprspec.Project, project draughtsmantprspec.Project) ([]draughtsmantprspec.Project, bool) {
-	if project.ID == "" || project.Name == "" || project.Ref == "" {
+// newProjectObject builds the map[string]interface{} representation of a
+// tracked project, stored in the TPO's spec.projects slice.
+func newProjectObject(id, name, ref string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":   id,
+		"name": name,
+		"ref":  ref,
+	}
+}
+
+// ensureProject takes care of updating the given projects slice with the
+// given project. In case the project cannot be found in the slice, it is
+// added. In case the project is found in the slice, it is updated, if it
+// changed. In case the slice got updated somehow the returned boolean is
+// true.
+func ensureProject(projects []interface{}, project map[string]interface{}) ([]interface{}, bool) {
+	id, _ := project["id"].(string)
+	name, _ := project["name"].(string)
+	ref, _ := project["ref"].(string)
+	if id == "" || name == "" || ref == "" {
 		return projects, false
 	}
 
-	_, err := getProjectByName(projects, project.Name)
+	_, err := getProjectByName(projects, name)
 	if IsNotFound(err) {
 		projects = append(projects, project)
 		return projects, true
 	}
 
 	for i, p := range projects {
-		if p.Name == project.Name && p.ID != project.ID {
+		existing, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		existingName, _ := existing["name"].(string)
+		existingID, _ := existing["id"].(string)
+		if existingName == name && existingID != id {
 			projects[i] = project
 			return projects, true
 		}
@@ -260,12 +353,17 @@ func ensureProject(projects []draughtsmantprspec.Project, project draughtsmantpr
 	return projects, false
 }
 
-func getProjectByName(projects []draughtsmantprspec.Project, name string) (draughtsmantprspec.Project, error) {
+func getProjectByName(projects []interface{}, name string) (map[string]interface{}, error) {
 	for _, p := range projects {
-		if p.Name == name {
-			return p, nil
+		project, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if projectName, _ := project["name"].(string); projectName == name {
+			return project, nil
 		}
 	}
 
-	return draughtsmantprspec.Project{}, microerror.Maskf(notFoundError, "project with name '%s'", name)
+	return nil, microerror.Maskf(notFoundError, "project with name '%s'", name)
 }
@@ -1,6 +1,7 @@
 package informer
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sync"
@@ -8,14 +9,60 @@ import (
 	"time"
 
 	"github.com/cenk/backoff"
-	"github.com/giantswarm/draughtsmantpr"
+	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger/microloggertest"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 
-	"github.com/giantswarm/draughtsman-eventer/service/eventer"
 	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
-	draughtsmantprspec "github.com/giantswarm/draughtsmantpr/spec"
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/webhook"
+	"github.com/giantswarm/draughtsman-eventer/service/tpo"
 )
 
+var testGVR = schema.GroupVersionResource{
+	Group:    "draughtsman.giantswarm.io",
+	Version:  "v1",
+	Resource: "draughtsmanconfigs",
+}
+
+// stubRESTMapper is a minimal meta.RESTMapper that always reports testGVR as
+// namespaced, without pulling in real discovery machinery.
+type stubRESTMapper struct {
+	meta.RESTMapper
+}
+
+func (m *stubRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	return &meta.RESTMapping{Scope: meta.RESTScopeNamespace}, nil
+}
+
+// newFakeTPOController returns a tpo.Service backed by a fake dynamic client,
+// so tests exercise the same Ensure/Get path production code does.
+func newFakeTPOController(t *testing.T) *tpo.Service {
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{
+		testGVR: "DraughtsmanConfigList",
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds)
+
+	tpoConfig := tpo.DefaultConfig()
+
+	tpoConfig.DynamicClient = dynamicClient
+	tpoConfig.Logger = microloggertest.New()
+	tpoConfig.RESTMapper = &stubRESTMapper{}
+
+	tpoConfig.GroupVersionResource = testGVR
+
+	tpoController, err := tpo.New(tpoConfig)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	return tpoController
+}
+
 func Test_Informer_BackOff_NoRetries(t *testing.T) {
 	environment := "master"
 	projects := []string{
@@ -31,7 +78,7 @@ func Test_Informer_BackOff_NoRetries(t *testing.T) {
 		Err:          fmt.Errorf("test error"),
 		GetCalled:    0,
 		Mutex:        sync.Mutex{},
-		TPO:          &draughtsmantpr.CustomObject{},
+		TPO:          &unstructured.Unstructured{Object: map[string]interface{}{}},
 	}
 
 	var newInformer *Service
@@ -52,7 +99,7 @@ func Test_Informer_BackOff_NoRetries(t *testing.T) {
 		}
 	}
 
-	newInformer.Boot()
+	newInformer.Boot(context.Background())
 
 	if tpoController.GetCalled != 1 {
 		t.Fatalf("expected %d got %d", 1, tpoController.GetCalled)
@@ -74,7 +121,7 @@ func Test_Informer_BackOff_MultipleRetries(t *testing.T) {
 		Err:          fmt.Errorf("test error"),
 		GetCalled:    0,
 		Mutex:        sync.Mutex{},
-		TPO:          &draughtsmantpr.CustomObject{},
+		TPO:          &unstructured.Unstructured{Object: map[string]interface{}{}},
 	}
 
 	var newInformer *Service
@@ -95,7 +142,7 @@ func Test_Informer_BackOff_MultipleRetries(t *testing.T) {
 		}
 	}
 
-	newInformer.Boot()
+	newInformer.Boot(context.Background())
 
 	if tpoController.GetCalled != 4 {
 		t.Fatalf("expected %d got %d", 4, tpoController.GetCalled)
@@ -110,15 +157,7 @@ func Test_Informer_EventManagement_NilTPO(t *testing.T) {
 		"api-name",
 	}
 
-	var err error
-
-	tpoController := &testTPOController{
-		EnsureCalled: 0,
-		Err:          nil, // Err is nil so the informer process goes on.
-		GetCalled:    0,
-		Mutex:        sync.Mutex{},
-		TPO:          nil, // TPO is nil so the code should not panic.
-	}
+	tpoController := newFakeTPOController(t)
 
 	te := &testEventer{
 		ContinuousEvents: nil,
@@ -131,6 +170,7 @@ func Test_Informer_EventManagement_NilTPO(t *testing.T) {
 		},
 	}
 
+	var err error
 	var newInformer *Service
 	{
 		informerConfig := DefaultConfig()
@@ -149,34 +189,24 @@ func Test_Informer_EventManagement_NilTPO(t *testing.T) {
 		}
 	}
 
-	go newInformer.Boot()
-
-	expectedEnsuredCalled := 1
+	go newInformer.Boot(context.Background())
 
 	done := make(chan struct{}, 1)
 	go func() {
 		for {
-			tpoController.Mutex.Lock()
-			if tpoController.EnsureCalled >= expectedEnsuredCalled {
-				tpoController.Mutex.Unlock()
+			_, err := tpoController.Get()
+			if err == nil {
 				break
 			}
-			tpoController.Mutex.Unlock()
 			time.Sleep(10 * time.Millisecond)
 		}
 		close(done)
 	}()
-	wait := func() {
-		for {
-			select {
-			case <-time.After(100 * time.Millisecond):
-				t.Fatalf("timed out")
-			case <-done:
-				return
-			}
-		}
+	select {
+	case <-time.After(100 * time.Millisecond):
+		t.Fatalf("timed out")
+	case <-done:
 	}
-	wait()
 }
 
 func Test_Informer_EventManagement(t *testing.T) {
@@ -188,10 +218,8 @@ func Test_Informer_EventManagement(t *testing.T) {
 	}
 
 	testCases := []struct {
-		Eventer               *testEventer
-		TPOController         *testTPOController
-		ExpectedEnsuredCalled int
-		ExpectedTPO           *draughtsmantpr.CustomObject
+		Eventer          *testEventer
+		ExpectedProjects []interface{}
 	}{
 		// Test 1 makes sure the deployment event of a single project will be
 		// tracked within the TPO based on its latest deployment event.
@@ -206,24 +234,8 @@ func Test_Informer_EventManagement(t *testing.T) {
 					},
 				},
 			},
-			TPOController: &testTPOController{
-				EnsureCalled: 0,
-				Err:          nil,
-				GetCalled:    0,
-				Mutex:        sync.Mutex{},
-				TPO:          &draughtsmantpr.CustomObject{},
-			},
-			ExpectedEnsuredCalled: 1,
-			ExpectedTPO: &draughtsmantpr.CustomObject{
-				Spec: draughtsmantpr.Spec{
-					Projects: []draughtsmantprspec.Project{
-						{
-							ID:   "100",
-							Name: "api-name",
-							Ref:  "api-sha-1",
-						},
-					},
-				},
+			ExpectedProjects: []interface{}{
+				newProjectObject("100", "api-name", "api-sha-1"),
 			},
 		},
 
@@ -245,29 +257,9 @@ func Test_Informer_EventManagement(t *testing.T) {
 					},
 				},
 			},
-			TPOController: &testTPOController{
-				EnsureCalled: 0,
-				Err:          nil,
-				GetCalled:    0,
-				Mutex:        sync.Mutex{},
-				TPO:          &draughtsmantpr.CustomObject{},
-			},
-			ExpectedEnsuredCalled: 1,
-			ExpectedTPO: &draughtsmantpr.CustomObject{
-				Spec: draughtsmantpr.Spec{
-					Projects: []draughtsmantprspec.Project{
-						{
-							ID:   "100",
-							Name: "api-name",
-							Ref:  "api-sha-1",
-						},
-						{
-							ID:   "101",
-							Name: "cluster-service-name",
-							Ref:  "cluster-service-sha-1",
-						},
-					},
-				},
+			ExpectedProjects: []interface{}{
+				newProjectObject("100", "api-name", "api-sha-1"),
+				newProjectObject("101", "cluster-service-name", "cluster-service-sha-1"),
 			},
 		},
 
@@ -291,87 +283,16 @@ func Test_Informer_EventManagement(t *testing.T) {
 					},
 				},
 			},
-			TPOController: &testTPOController{
-				EnsureCalled: 0,
-				Err:          nil,
-				GetCalled:    0,
-				Mutex:        sync.Mutex{},
-				TPO:          &draughtsmantpr.CustomObject{},
-			},
-			ExpectedEnsuredCalled: 2,
-			ExpectedTPO: &draughtsmantpr.CustomObject{
-				Spec: draughtsmantpr.Spec{
-					Projects: []draughtsmantprspec.Project{
-						{
-							ID:   "101",
-							Name: "api-name",
-							Ref:  "api-sha-2",
-						},
-					},
-				},
-			},
-		},
-
-		// Test 4 makes sure the deployment events of multiple projects will be
-		// tracked within the TPO based on their continuous deployment events, which
-		// overwrite the latest ones.
-		{
-			Eventer: &testEventer{
-				ContinuousEvents: map[string]eventerspec.DeploymentEvent{
-					"api-name": {
-						ID:   101,
-						Name: "api-name",
-						Sha:  "api-sha-2",
-					},
-					"cluster-service-name": {
-						ID:   103,
-						Name: "cluster-service-name",
-						Sha:  "cluster-service-sha-2",
-					},
-				},
-				LatestEvents: map[string]eventerspec.DeploymentEvent{
-					"api-name": {
-						ID:   100,
-						Name: "api-name",
-						Sha:  "api-sha-1",
-					},
-					"cluster-service-name": {
-						ID:   102,
-						Name: "cluster-service-name",
-						Sha:  "cluster-service-sha-1",
-					},
-				},
-			},
-			TPOController: &testTPOController{
-				EnsureCalled: 0,
-				Err:          nil,
-				GetCalled:    0,
-				Mutex:        sync.Mutex{},
-				TPO:          &draughtsmantpr.CustomObject{},
-			},
-			ExpectedEnsuredCalled: 3,
-			ExpectedTPO: &draughtsmantpr.CustomObject{
-				Spec: draughtsmantpr.Spec{
-					Projects: []draughtsmantprspec.Project{
-						{
-							ID:   "101",
-							Name: "api-name",
-							Ref:  "api-sha-2",
-						},
-						{
-							ID:   "103",
-							Name: "cluster-service-name",
-							Ref:  "cluster-service-sha-2",
-						},
-					},
-				},
+			ExpectedProjects: []interface{}{
+				newProjectObject("101", "api-name", "api-sha-2"),
 			},
 		},
 	}
 
 	for i, tc := range testCases {
-		var err error
+		tpoController := newFakeTPOController(t)
 
+		var err error
 		var newInformer *Service
 		{
 			informerConfig := DefaultConfig()
@@ -379,7 +300,7 @@ func Test_Informer_EventManagement(t *testing.T) {
 			informerConfig.BackOff = &backoff.ZeroBackOff{}
 			informerConfig.Eventer = tc.Eventer
 			informerConfig.Logger = microloggertest.New()
-			informerConfig.TPO = tc.TPOController
+			informerConfig.TPO = tpoController
 
 			informerConfig.Environment = environment
 			informerConfig.Projects = projects
@@ -390,136 +311,89 @@ func Test_Informer_EventManagement(t *testing.T) {
 			}
 		}
 
-		go newInformer.Boot()
+		go newInformer.Boot(context.Background())
 
+		var TPO *unstructured.Unstructured
 		done := make(chan struct{}, 1)
 		go func() {
 			for {
-				tc.TPOController.Mutex.Lock()
-				if tc.TPOController.EnsureCalled >= tc.ExpectedEnsuredCalled {
-					tc.TPOController.Mutex.Unlock()
-					break
+				tpo, err := tpoController.Get()
+				if err == nil {
+					projects, _, _ := unstructured.NestedSlice(tpo.Object, projectsFieldPath...)
+					if len(projects) == len(tc.ExpectedProjects) {
+						TPO = tpo
+						break
+					}
 				}
-				tc.TPOController.Mutex.Unlock()
 				time.Sleep(10 * time.Millisecond)
 			}
 			close(done)
 		}()
-		wait := func() {
-			for {
-				select {
-				case <-time.After(100 * time.Millisecond):
-					t.Fatalf("test %d timed out", i+1)
-				case <-done:
-					return
-				}
-			}
+		select {
+		case <-time.After(200 * time.Millisecond):
+			t.Fatalf("test %d timed out", i+1)
+		case <-done:
 		}
-		wait()
 
-		controllerTPO, err := tc.TPOController.Get()
+		projects, _, err := unstructured.NestedSlice(TPO.Object, projectsFieldPath...)
 		if err != nil {
 			t.Fatalf("test %d expected %#v got %#v", i+1, nil, err)
 		}
-		if !reflect.DeepEqual(controllerTPO.Spec.Projects, tc.ExpectedTPO.Spec.Projects) {
-			t.Fatalf("test %d expected %#v got %#v", i+1, tc.ExpectedTPO.Spec.Projects, controllerTPO.Spec.Projects)
+		if !reflect.DeepEqual(projects, tc.ExpectedProjects) {
+			t.Fatalf("test %d expected %#v got %#v", i+1, tc.ExpectedProjects, projects)
 		}
 	}
 }
 
 func Test_Informer_ensureProject(t *testing.T) {
 	testCases := []struct {
-		Projects         []draughtsmantprspec.Project
-		Project          draughtsmantprspec.Project
-		ExpectedProjects []draughtsmantprspec.Project
+		Projects         []interface{}
+		Project          map[string]interface{}
+		ExpectedProjects []interface{}
 		ExpectedUpdated  bool
 	}{
 		// Test 1
 		{
-			Projects:         []draughtsmantprspec.Project{},
-			Project:          draughtsmantprspec.Project{},
-			ExpectedProjects: []draughtsmantprspec.Project{},
+			Projects:         []interface{}{},
+			Project:          map[string]interface{}{},
+			ExpectedProjects: []interface{}{},
 			ExpectedUpdated:  false,
 		},
 
 		// Test 2
 		{
-			Projects: []draughtsmantprspec.Project{
-				{
-					ID:   "api-id-1",
-					Name: "api-name",
-					Ref:  "api-sha-1",
-				},
+			Projects: []interface{}{
+				newProjectObject("api-id-1", "api-name", "api-sha-1"),
 			},
-			Project: draughtsmantprspec.Project{
-				ID:   "api-id-1",
-				Name: "api-name",
-				Ref:  "api-sha-1",
-			},
-			ExpectedProjects: []draughtsmantprspec.Project{
-				{
-					ID:   "api-id-1",
-					Name: "api-name",
-					Ref:  "api-sha-1",
-				},
+			Project: newProjectObject("api-id-1", "api-name", "api-sha-1"),
+			ExpectedProjects: []interface{}{
+				newProjectObject("api-id-1", "api-name", "api-sha-1"),
 			},
 			ExpectedUpdated: false,
 		},
 
 		// Test 3
 		{
-			Projects: []draughtsmantprspec.Project{
-				{
-					ID:   "api-id-1",
-					Name: "api-name",
-					Ref:  "api-sha-1",
-				},
+			Projects: []interface{}{
+				newProjectObject("api-id-1", "api-name", "api-sha-1"),
 			},
-			Project: draughtsmantprspec.Project{
-				ID:   "api-id-2",
-				Name: "api-name",
-				Ref:  "api-sha-2",
-			},
-			ExpectedProjects: []draughtsmantprspec.Project{
-				{
-					ID:   "api-id-2",
-					Name: "api-name",
-					Ref:  "api-sha-2",
-				},
+			Project: newProjectObject("api-id-2", "api-name", "api-sha-2"),
+			ExpectedProjects: []interface{}{
+				newProjectObject("api-id-2", "api-name", "api-sha-2"),
 			},
 			ExpectedUpdated: true,
 		},
 
 		// Test 4
 		{
-			Projects: []draughtsmantprspec.Project{
-				{
-					ID:   "api-id-1",
-					Name: "api-name",
-					Ref:  "api-sha-1",
-				},
-				{
-					ID:   "cluster-service-id-1",
-					Name: "cluster-service-name",
-					Ref:  "cluster-service-sha-1",
-				},
+			Projects: []interface{}{
+				newProjectObject("api-id-1", "api-name", "api-sha-1"),
+				newProjectObject("cluster-service-id-1", "cluster-service-name", "cluster-service-sha-1"),
 			},
-			Project: draughtsmantprspec.Project{
-				ID:   "api-id-2",
-				Name: "api-name",
-				Ref:  "api-sha-2",
-			},
-			ExpectedProjects: []draughtsmantprspec.Project{
-				{
-					ID:   "api-id-2",
-					Name: "api-name",
-					Ref:  "api-sha-2",
-				},
-				{
-					ID:   "cluster-service-id-1",
-					Name: "cluster-service-name",
-					Ref:  "cluster-service-sha-1",
-				},
+			Project: newProjectObject("api-id-2", "api-name", "api-sha-2"),
+			ExpectedProjects: []interface{}{
+				newProjectObject("api-id-2", "api-name", "api-sha-2"),
+				newProjectObject("cluster-service-id-1", "cluster-service-name", "cluster-service-sha-1"),
 			},
 			ExpectedUpdated: true,
 		},
@@ -536,15 +410,18 @@ func Test_Informer_ensureProject(t *testing.T) {
 	}
 }
 
+// testTPOController is a minimal, directly-controllable tpo.Controller double
+// used to exercise the informer's boot/backoff behavior without going
+// through an actual TPO storage backend.
 type testTPOController struct {
 	EnsureCalled int
 	Err          error
 	GetCalled    int
 	Mutex        sync.Mutex
-	TPO          *draughtsmantpr.CustomObject
+	TPO          *unstructured.Unstructured
 }
 
-func (c *testTPOController) Ensure(TPO *draughtsmantpr.CustomObject) error {
+func (c *testTPOController) Ensure(TPO *unstructured.Unstructured) error {
 	c.Mutex.Lock()
 	c.TPO = TPO
 	c.EnsureCalled++
@@ -552,17 +429,23 @@ func (c *testTPOController) Ensure(TPO *draughtsmantpr.CustomObject) error {
 	return c.Err
 }
 
-func (c *testTPOController) Get() (*draughtsmantpr.CustomObject, error) {
+func (c *testTPOController) Get() (*unstructured.Unstructured, error) {
 	c.GetCalled++
 	return c.TPO, c.Err
 }
 
+func (c *testTPOController) Watch(ctx context.Context) (<-chan *unstructured.Unstructured, error) {
+	tpoChannel := make(chan *unstructured.Unstructured)
+	close(tpoChannel)
+	return tpoChannel, nil
+}
+
 type testEventer struct {
 	ContinuousEvents map[string]eventerspec.DeploymentEvent
 	LatestEvents     map[string]eventerspec.DeploymentEvent
 }
 
-func (e *testEventer) FetchContinuously(projects []string, environment string) (<-chan eventerspec.DeploymentEvent, error) {
+func (e *testEventer) FetchContinuously(ctx context.Context, projects []string, environment string) (<-chan eventerspec.DeploymentEvent, error) {
 	deploymentEventChannel := make(chan eventerspec.DeploymentEvent, len(e.ContinuousEvents))
 
 	for project, deploymentEvent := range e.ContinuousEvents {
@@ -582,7 +465,10 @@ func (e *testEventer) FetchLatest(project, environment string) (eventerspec.Depl
 		return event, nil
 	}
 
-	return eventerspec.DeploymentEvent{}, eventer.NotFoundError
+	// A webhook Eventer without a Source always reports not found, with an
+	// error eventer.IsNotFound recognizes, so the informer skips the project.
+	_, err := (&webhook.Eventer{}).FetchLatest(project, environment)
+	return eventerspec.DeploymentEvent{}, microerror.Mask(err)
 }
 
 func (e *testEventer) SetPendingStatus(event eventerspec.DeploymentEvent) error {
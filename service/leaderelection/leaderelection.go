@@ -0,0 +1,176 @@
+// Package leaderelection lets multiple informer replicas run safely by
+// ensuring only one of them acts as leader at a time, using a Kubernetes
+// Lease as the lock.
+package leaderelection
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/giantswarm/draughtsman-eventer/service/metrics"
+)
+
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+	defaultRetryPeriod   = 2 * time.Second
+)
+
+// Config represents the configuration used to create a Service.
+type Config struct {
+	// Dependencies.
+	K8sClient kubernetes.Interface
+	Logger    micrologger.Logger
+
+	// Settings.
+	// Identity uniquely identifies this replica among its peers, e.g. its pod
+	// name.
+	Identity string
+	// LockName is the name of the Lease object used as the lock.
+	LockName string
+	// Namespace is the namespace the Lease lives in.
+	Namespace string
+}
+
+// DefaultConfig provides a default configuration to create a new Service by
+// best effort.
+func DefaultConfig() Config {
+	return Config{
+		// Dependencies.
+		K8sClient: nil,
+		Logger:    nil,
+
+		// Settings.
+		Identity:  "",
+		LockName:  "",
+		Namespace: "",
+	}
+}
+
+// Service runs leader election so only one replica of the informer is
+// active at a time.
+type Service struct {
+	// Dependencies.
+	k8sClient kubernetes.Interface
+	logger    micrologger.Logger
+
+	// Internals.
+	mutex    sync.RWMutex
+	isLeader bool
+
+	// Settings.
+	identity  string
+	lockName  string
+	namespace string
+}
+
+// New creates a new configured Service.
+func New(config Config) (*Service, error) {
+	// Dependencies.
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.K8sClient must not be empty")
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Logger must not be empty")
+	}
+
+	// Settings.
+	if config.Identity == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.Identity must not be empty")
+	}
+	if config.LockName == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.LockName must not be empty")
+	}
+	if config.Namespace == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.Namespace must not be empty")
+	}
+
+	s := &Service{
+		// Dependencies.
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+
+		// Settings.
+		identity:  config.Identity,
+		lockName:  config.LockName,
+		namespace: config.Namespace,
+	}
+
+	return s, nil
+}
+
+// IsLeader reports whether this replica currently holds the lease.
+func (s *Service) IsLeader() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.isLeader
+}
+
+// Run blocks, participating in leader election until this replica loses the
+// lease or ctx is cancelled. Once the lease is acquired, onStartedLeading is
+// called; onStoppedLeading is called when the lease is lost, and Run then
+// returns. Run does not enter the election again afterwards, so
+// onStartedLeading is called at most once per Run call.
+//
+// Note: onStartedLeading's context is cancelled on lease loss, but it is the
+// callback's own responsibility to react to that by returning; Run does not
+// forcibly tear down whatever onStartedLeading started.
+func (s *Service) Run(ctx context.Context, onStartedLeading func(ctx context.Context), onStoppedLeading func()) error {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      s.lockName,
+			Namespace: s.namespace,
+		},
+		Client: s.k8sClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: s.identity,
+		},
+	}
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: defaultLeaseDuration,
+		RenewDeadline: defaultRenewDeadline,
+		RetryPeriod:   defaultRetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leadingCtx context.Context) {
+				s.setLeader(true)
+				s.logger.Log("debug", "acquired leader election lease", "identity", s.identity)
+				onStartedLeading(leadingCtx)
+			},
+			OnStoppedLeading: func() {
+				s.setLeader(false)
+				s.logger.Log("warning", "lost leader election lease", "identity", s.identity)
+				onStoppedLeading()
+			},
+		},
+	})
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	elector.Run(ctx)
+
+	return nil
+}
+
+func (s *Service) setLeader(leader bool) {
+	s.mutex.Lock()
+	s.isLeader = leader
+	s.mutex.Unlock()
+
+	if leader {
+		metrics.InformerLeaderStatus.Set(1)
+	} else {
+		metrics.InformerLeaderStatus.Set(0)
+	}
+}
@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+
+	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
+)
+
+// MonitoredEventerConfig represents the configuration used to create a
+// MonitoredEventer.
+type MonitoredEventerConfig struct {
+	// Dependencies.
+	Eventer eventerspec.Eventer
+
+	// Settings.
+	// Provider is the label recorded for events passing through this
+	// delegator, e.g. "GithubEventer", "GitLabEventer" or "BitbucketEventer".
+	Provider string
+}
+
+// DefaultMonitoredEventerConfig provides a default configuration to create a
+// new MonitoredEventer by best effort.
+func DefaultMonitoredEventerConfig() MonitoredEventerConfig {
+	return MonitoredEventerConfig{
+		// Dependencies.
+		Eventer: nil,
+
+		// Settings.
+		Provider: "",
+	}
+}
+
+// MonitoredEventer wraps an eventerspec.Eventer, emitting Prometheus counters
+// for every event received and every status posted.
+type MonitoredEventer struct {
+	// Dependencies.
+	eventer eventerspec.Eventer
+
+	// Settings.
+	provider string
+}
+
+// NewMonitoredEventer creates a new MonitoredEventer delegating to
+// config.Eventer.
+func NewMonitoredEventer(config MonitoredEventerConfig) (*MonitoredEventer, error) {
+	// Dependencies.
+	if config.Eventer == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.Eventer must not be empty")
+	}
+
+	// Settings.
+	if config.Provider == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.Provider must not be empty")
+	}
+
+	e := &MonitoredEventer{
+		// Dependencies.
+		eventer: config.Eventer,
+
+		// Settings.
+		provider: config.Provider,
+	}
+
+	return e, nil
+}
+
+func (e *MonitoredEventer) FetchContinuously(ctx context.Context, projects []string, environment string) (<-chan eventerspec.DeploymentEvent, error) {
+	in, err := e.eventer.FetchContinuously(ctx, projects, environment)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	out := make(chan eventerspec.DeploymentEvent)
+
+	go func() {
+		defer close(out)
+
+		for event := range in {
+			EventsReceivedTotal.WithLabelValues(event.Name, environment, e.provider).Inc()
+
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (e *MonitoredEventer) FetchLatest(project, environment string) (eventerspec.DeploymentEvent, error) {
+	event, err := e.eventer.FetchLatest(project, environment)
+	if err == nil {
+		EventsReceivedTotal.WithLabelValues(project, environment, e.provider).Inc()
+	}
+
+	return event, err
+}
+
+func (e *MonitoredEventer) SetPendingStatus(event eventerspec.DeploymentEvent) error {
+	err := e.eventer.SetPendingStatus(event)
+
+	StatusPostTotal.WithLabelValues(event.Name, "pending", resultLabel(err)).Inc()
+
+	return err
+}
@@ -0,0 +1,177 @@
+// Package metrics centralizes the Prometheus collectors used to give
+// operators visibility into the eventer and informer loops.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// EventsReceivedTotal counts deployment events received from an Eventer,
+	// whether polled or pushed in over a webhook.
+	EventsReceivedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "eventer",
+			Name:      "events_received_total",
+			Help:      "Total number of deployment events received, by project, environment and provider.",
+		},
+		[]string{"project", "environment", "provider"},
+	)
+
+	// StatusPostTotal counts attempts to post a deployment status back to the
+	// event source.
+	StatusPostTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "eventer",
+			Name:      "status_post_total",
+			Help:      "Total number of deployment status updates posted, by project, state and result.",
+		},
+		[]string{"project", "state", "result"},
+	)
+
+	// TPOEnsureTotal counts attempts by the informer to ensure the TPO
+	// reflects a deployment event.
+	TPOEnsureTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "informer",
+			Name:      "tpo_ensure_total",
+			Help:      "Total number of TPO ensure calls made by the informer, by result.",
+		},
+		[]string{"result"},
+	)
+
+	// TPOUpdatesTotal counts TPO updates made by the informer on behalf of a
+	// specific project, broken out from TPOEnsureTotal so operators can see
+	// which projects are driving TPO churn.
+	TPOUpdatesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "informer",
+			Name:      "tpo_updates_total",
+			Help:      "Total number of TPO updates made by the informer, by project and result.",
+		},
+		[]string{"project", "result"},
+	)
+
+	// InformerBootRetriesTotal counts how many times the informer has had to
+	// retry its boot loop after a failure.
+	InformerBootRetriesTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "informer",
+			Name:      "boot_retries_total",
+			Help:      "Total number of times the informer boot loop has been retried after a failure.",
+		},
+	)
+
+	// InformerLastEventTimestamp records the Unix timestamp of the last
+	// deployment event the informer processed for a project, so operators can
+	// alert when a project stops receiving events.
+	InformerLastEventTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "informer",
+			Name:      "last_event_timestamp_seconds",
+			Help:      "Unix timestamp of the last deployment event processed, by project.",
+		},
+		[]string{"project"},
+	)
+
+	// DeploymentEventsFetchedTotal counts attempts by the GitHub eventer to
+	// fetch new deployment events for a project.
+	DeploymentEventsFetchedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "eventer",
+			Name:      "deployment_events_fetched_total",
+			Help:      "Total number of deployment event fetches, by project, environment and result.",
+		},
+		[]string{"project", "environment", "result"},
+	)
+
+	// GitHubAPIRequestsTotal counts outbound requests made to the GitHub API,
+	// by response status code.
+	GitHubAPIRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "eventer",
+			Subsystem: "github",
+			Name:      "api_requests_total",
+			Help:      "Total number of requests made to the GitHub API, by response status code.",
+		},
+		[]string{"status"},
+	)
+
+	// GitHubAPIRequestDuration observes how long requests to the GitHub API
+	// take to complete.
+	GitHubAPIRequestDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "eventer",
+			Subsystem: "github",
+			Name:      "api_request_duration_seconds",
+			Help:      "Duration of requests made to the GitHub API.",
+		},
+	)
+
+	// GitHubRateLimitRemaining tracks the most recently observed
+	// X-RateLimit-Remaining value, so operators can alert before polling
+	// stalls due to rate limiting.
+	GitHubRateLimitRemaining = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "eventer",
+			Subsystem: "github",
+			Name:      "rate_limit_remaining",
+			Help:      "Number of GitHub API requests remaining in the current rate limit window.",
+		},
+	)
+
+	// GitHubRateLimitReset tracks the most recently observed
+	// X-RateLimit-Reset value, as a Unix timestamp.
+	GitHubRateLimitReset = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "eventer",
+			Subsystem: "github",
+			Name:      "rate_limit_reset_seconds",
+			Help:      "Unix timestamp at which the current GitHub rate limit window resets.",
+		},
+	)
+
+	// InformerLeaderStatus is 1 while this replica holds the informer's
+	// leader election lease, and 0 otherwise, so HA deployments can alert if
+	// no replica (or more than one) believes itself to be leading.
+	InformerLeaderStatus = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "informer",
+			Name:      "leader_status",
+			Help:      "1 if this replica currently holds the informer leader election lease, 0 otherwise.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(EventsReceivedTotal)
+	prometheus.MustRegister(StatusPostTotal)
+	prometheus.MustRegister(TPOEnsureTotal)
+	prometheus.MustRegister(TPOUpdatesTotal)
+	prometheus.MustRegister(InformerBootRetriesTotal)
+	prometheus.MustRegister(InformerLastEventTimestamp)
+	prometheus.MustRegister(DeploymentEventsFetchedTotal)
+	prometheus.MustRegister(GitHubAPIRequestsTotal)
+	prometheus.MustRegister(GitHubAPIRequestDuration)
+	prometheus.MustRegister(GitHubRateLimitRemaining)
+	prometheus.MustRegister(GitHubRateLimitReset)
+	prometheus.MustRegister(InformerLeaderStatus)
+}
+
+// Handler returns the HTTP handler that serves the collectors registered by
+// this package, to be mounted at "/metrics" alongside healthz.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// resultLabel returns the "result" label value to record for err.
+func resultLabel(err error) string {
+	if err != nil {
+		return "error"
+	}
+
+	return "success"
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/giantswarm/draughtsman-eventer/service/tpo"
+)
+
+// MonitoredTPOControllerConfig represents the configuration used to create a
+// MonitoredTPOController.
+type MonitoredTPOControllerConfig struct {
+	// Dependencies.
+	TPO tpo.Controller
+}
+
+// DefaultMonitoredTPOControllerConfig provides a default configuration to
+// create a new MonitoredTPOController by best effort.
+func DefaultMonitoredTPOControllerConfig() MonitoredTPOControllerConfig {
+	return MonitoredTPOControllerConfig{
+		// Dependencies.
+		TPO: nil,
+	}
+}
+
+// MonitoredTPOController wraps a tpo.Controller, emitting a Prometheus
+// counter for every Ensure call.
+type MonitoredTPOController struct {
+	// Dependencies.
+	tpo tpo.Controller
+}
+
+// NewMonitoredTPOController creates a new MonitoredTPOController delegating
+// to config.TPO.
+func NewMonitoredTPOController(config MonitoredTPOControllerConfig) (*MonitoredTPOController, error) {
+	// Dependencies.
+	if config.TPO == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.TPO must not be empty")
+	}
+
+	c := &MonitoredTPOController{
+		// Dependencies.
+		tpo: config.TPO,
+	}
+
+	return c, nil
+}
+
+func (c *MonitoredTPOController) Ensure(TPO *unstructured.Unstructured) error {
+	err := c.tpo.Ensure(TPO)
+
+	TPOEnsureTotal.WithLabelValues(resultLabel(err)).Inc()
+
+	return err
+}
+
+func (c *MonitoredTPOController) Get() (*unstructured.Unstructured, error) {
+	return c.tpo.Get()
+}
+
+func (c *MonitoredTPOController) Watch(ctx context.Context) (<-chan *unstructured.Unstructured, error) {
+	return c.tpo.Watch(ctx)
+}
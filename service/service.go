@@ -3,6 +3,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -16,13 +17,24 @@ import (
 	"github.com/giantswarm/micrologger"
 	"github.com/giantswarm/operatorkit/client/k8s"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
 
 	"github.com/giantswarm/draughtsman-eventer/flag"
+	"github.com/giantswarm/draughtsman-eventer/pkg/log"
 	"github.com/giantswarm/draughtsman-eventer/service/eventer"
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/bitbucket"
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/github"
+	"github.com/giantswarm/draughtsman-eventer/service/eventer/gitlab"
 	eventerspec "github.com/giantswarm/draughtsman-eventer/service/eventer/spec"
 	"github.com/giantswarm/draughtsman-eventer/service/healthz"
 	"github.com/giantswarm/draughtsman-eventer/service/informer"
+	"github.com/giantswarm/draughtsman-eventer/service/leaderelection"
+	"github.com/giantswarm/draughtsman-eventer/service/metrics"
 	"github.com/giantswarm/draughtsman-eventer/service/tpo"
 )
 
@@ -66,7 +78,9 @@ type Service struct {
 	Version  *version.Service
 
 	// Internals.
-	bootOnce sync.Once
+	bootOnce       sync.Once
+	leaderElection *leaderelection.Service
+	logger         micrologger.Logger
 }
 
 // New creates a new configured service object.
@@ -87,12 +101,25 @@ func New(config Config) (*Service, error) {
 
 	var err error
 
-	var k8sClient kubernetes.Interface
+	var logger micrologger.Logger
 	{
-		k8sConfig := k8s.DefaultConfig()
+		logConfig := log.DefaultConfig()
+
+		logConfig.Encoding = config.Viper.GetString(config.Flag.Service.Log.Encoding)
+		logConfig.Level = config.Viper.GetString(config.Flag.Service.Log.Level)
+
+		logger, err = log.New(logConfig)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
 
+	k8sConfig := k8s.DefaultConfig()
+
+	var k8sClient kubernetes.Interface
+	{
 		k8sConfig.Address = config.Viper.GetString(config.Flag.Service.Kubernetes.Address)
-		k8sConfig.Logger = config.Logger
+		k8sConfig.Logger = logger
 		k8sConfig.InCluster = config.Viper.GetBool(config.Flag.Service.Kubernetes.InCluster)
 		k8sConfig.TLS.CAFile = config.Viper.GetString(config.Flag.Service.Kubernetes.TLS.CAFile)
 		k8sConfig.TLS.CrtFile = config.Viper.GetString(config.Flag.Service.Kubernetes.TLS.CrtFile)
@@ -121,7 +148,7 @@ func New(config Config) (*Service, error) {
 		healthzConfig := healthz.DefaultConfig()
 
 		healthzConfig.K8sClient = k8sClient
-		healthzConfig.Logger = config.Logger
+		healthzConfig.Logger = logger
 
 		healthzService, err = healthz.New(healthzConfig)
 		if err != nil {
@@ -134,7 +161,7 @@ func New(config Config) (*Service, error) {
 		eventerConfig := eventer.DefaultConfig()
 
 		eventerConfig.HTTPClient = httpClient
-		eventerConfig.Logger = config.Logger
+		eventerConfig.Logger = logger
 
 		eventerConfig.Flag = config.Flag
 		eventerConfig.Viper = config.Viper
@@ -143,19 +170,101 @@ func New(config Config) (*Service, error) {
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
+
+		monitoredEventerConfig := metrics.DefaultMonitoredEventerConfig()
+
+		monitoredEventerConfig.Eventer = eventerService
+		monitoredEventerConfig.Provider = config.Viper.GetString(config.Flag.Service.Eventer.Type)
+		if monitoredEventerConfig.Provider == "" {
+			// An empty eventer type selects the GitHub eventer, see eventer.New.
+			monitoredEventerConfig.Provider = string(github.GithubEventerType)
+		}
+
+		eventerService, err = metrics.NewMonitoredEventer(monitoredEventerConfig)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
 	}
 
-	var tpoService *tpo.Service
+	var dynamicClient dynamic.Interface
+	{
+		dynamicClient, err = dynamic.NewForConfig(k8sConfig.RestConfig)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var restMapper meta.RESTMapper
+	{
+		discoveryClient, err := discovery.NewDiscoveryClientForConfig(k8sConfig.RestConfig)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		restMapper = restmapper.NewDiscoveryRESTMapper(groupResources)
+	}
+
+	var tpoService tpo.Controller
 	{
 		tpoConfig := tpo.DefaultConfig()
 
-		tpoConfig.K8sClient = k8sClient
-		tpoConfig.Logger = config.Logger
+		tpoConfig.DynamicClient = dynamicClient
+		tpoConfig.Logger = logger
+		tpoConfig.RESTMapper = restMapper
+
+		tpoConfig.GroupVersionResource = schema.GroupVersionResource{
+			Group:    "draughtsman.giantswarm.io",
+			Version:  "v1",
+			Resource: "draughtsmanconfigs",
+		}
 
 		tpoService, err = tpo.New(tpoConfig)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
+
+		monitoredTPOConfig := metrics.DefaultMonitoredTPOControllerConfig()
+
+		monitoredTPOConfig.TPO = tpoService
+
+		tpoService, err = metrics.NewMonitoredTPOController(monitoredTPOConfig)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var leaderElectionService *leaderelection.Service
+	{
+		if config.Viper.GetBool(config.Flag.Service.LeaderElection.Enabled) {
+			leaderElectionConfig := leaderelection.DefaultConfig()
+
+			leaderElectionConfig.K8sClient = k8sClient
+			leaderElectionConfig.Logger = logger
+
+			leaderElectionConfig.Identity = config.Viper.GetString(config.Flag.Service.LeaderElection.Identity)
+			if leaderElectionConfig.Identity == "" {
+				leaderElectionConfig.Identity, err = os.Hostname()
+				if err != nil {
+					return nil, microerror.Mask(err)
+				}
+			}
+			leaderElectionConfig.LockName = config.Viper.GetString(config.Flag.Service.LeaderElection.LockName)
+			leaderElectionConfig.Namespace = config.Viper.GetString(config.Flag.Service.LeaderElection.Namespace)
+
+			leaderElectionService, err = leaderelection.New(leaderElectionConfig)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+
+			// This replica has not acquired the lease yet, so healthz must not
+			// report it as leader until Boot's OnStartedLeading callback fires.
+			healthzService.SetLeader(false)
+		}
 	}
 
 	var informerBackOff *backoff.ExponentialBackOff
@@ -171,11 +280,11 @@ func New(config Config) (*Service, error) {
 		informerConfig.BackOff = informerBackOff
 		informerConfig.Eventer = eventerService
 		informerConfig.ExitFunc = os.Exit
-		informerConfig.Logger = config.Logger
+		informerConfig.Logger = logger
 		informerConfig.TPO = tpoService
 
 		informerConfig.Environment = config.Viper.GetString(config.Flag.Service.Eventer.Environment)
-		informerConfig.Projects = strings.Split(config.Viper.GetString(config.Flag.Service.Eventer.GitHub.Projects), ",")
+		informerConfig.Projects = strings.Split(projectsForEventerType(config), ",")
 
 		informerService, err = informer.New(informerConfig)
 		if err != nil {
@@ -205,14 +314,59 @@ func New(config Config) (*Service, error) {
 		Version:  versionService,
 
 		// Internals
-		bootOnce: sync.Once{},
+		bootOnce:       sync.Once{},
+		leaderElection: leaderElectionService,
+		logger:         logger,
 	}
 
 	return newService, nil
 }
 
+// Boot starts the informer. If leader election is enabled, the informer is
+// only started once this replica has acquired the leader election lease; its
+// context is cancelled as soon as the lease is lost, which stops the
+// informer's FetchContinuously loop and closes its deployment event channel,
+// so a lease hand-off does not leave two replicas informing at once.
 func (s *Service) Boot() {
 	s.bootOnce.Do(func() {
-		s.Informer.Boot()
+		if s.leaderElection == nil {
+			s.Informer.Boot(context.Background())
+			return
+		}
+
+		err := s.leaderElection.Run(
+			context.Background(),
+			func(ctx context.Context) {
+				if s.Healthz != nil {
+					s.Healthz.SetLeader(true)
+				}
+				s.Informer.Boot(ctx)
+			},
+			func() {
+				if s.Healthz != nil {
+					s.Healthz.SetLeader(false)
+				}
+			},
+		)
+		if err != nil {
+			s.logger.Log("error", fmt.Sprintf("stop leader election due to error: %#v", microerror.Mask(err)))
+		}
 	})
 }
+
+// projectsForEventerType returns the comma-separated project list configured
+// for whichever eventer backend is selected by config.Flag.Service.Eventer.Type,
+// so the informer watches the projects the active provider actually knows
+// about.
+func projectsForEventerType(config Config) string {
+	eventerType := eventerspec.EventerType(config.Viper.GetString(config.Flag.Service.Eventer.Type))
+
+	switch eventerType {
+	case gitlab.GitLabEventerType:
+		return config.Viper.GetString(config.Flag.Service.Eventer.GitLab.Projects)
+	case bitbucket.BitbucketEventerType:
+		return config.Viper.GetString(config.Flag.Service.Eventer.Bitbucket.Projects)
+	default:
+		return config.Viper.GetString(config.Flag.Service.Eventer.GitHub.Projects)
+	}
+}
@@ -0,0 +1,28 @@
+package tpo
+
+import (
+	"github.com/giantswarm/microerror"
+)
+
+var invalidConfigError = microerror.New("invalid config")
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var notFoundError = microerror.New("not found")
+
+// IsNotFound asserts notFoundError.
+func IsNotFound(err error) bool {
+	return microerror.Cause(err) == notFoundError
+}
+
+var conflictError = microerror.New("conflict")
+
+// IsConflict asserts conflictError. It is returned by Ensure when the TPO
+// was modified since it was last fetched, so the caller lost the race and
+// should re-fetch before retrying its update.
+func IsConflict(err error) bool {
+	return microerror.Cause(err) == conflictError
+}
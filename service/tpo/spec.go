@@ -1,8 +1,26 @@
 package tpo
 
-import "github.com/giantswarm/draughtsmantpr"
+import (
+	"context"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Controller manages the lifecycle of the TPO custom resource the informer
+// keeps in sync with incoming deployment events. It operates on
+// unstructured.Unstructured rather than a generated draughtsmantpr.CustomObject,
+// so new fields added to the draughtsman CRD show up without a client
+// regeneration.
 type Controller interface {
-	Ensure(TPO *draughtsmantpr.CustomObject) error
-	Get() (*draughtsmantpr.CustomObject, error)
+	// Ensure creates the TPO if it does not exist, or updates it otherwise.
+	// TPO must carry the ResourceVersion of the object it was read from, so
+	// updates racing an external edit fail with IsConflict instead of
+	// silently overwriting it.
+	Ensure(TPO *unstructured.Unstructured) error
+	Get() (*unstructured.Unstructured, error)
+	// Watch streams the TPO every time it changes, including edits made by
+	// something other than this process, so consumers are not limited to
+	// reacting to their own eventer-driven updates. The underlying watch is
+	// stopped and the returned channel closed once ctx is cancelled.
+	Watch(ctx context.Context) (<-chan *unstructured.Unstructured, error)
 }
@@ -1,14 +1,19 @@
 package tpo
 
 import (
-	"encoding/json"
+	"context"
 
-	"github.com/giantswarm/draughtsmantpr"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
-	"github.com/giantswarm/operatorkit/tpr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/giantswarm/draughtsman-eventer/pkg/log"
 )
 
 const (
@@ -16,13 +21,26 @@ const (
 	DefaultNamespace = "default"
 	// Name is the name of the TPO the eventer watches.
 	Name = "draughtsman-tpo"
+
+	// APIVersion and Kind are stamped onto TPOs created by the informer, so
+	// callers constructing a fresh object don't need to know the draughtsman
+	// CRD's group/version/kind themselves.
+	APIVersion = "draughtsman.giantswarm.io/v1"
+	Kind       = "DraughtsmanConfig"
 )
 
 // Config represents the configuration used to create a TPO service.
 type Config struct {
 	// Dependencies.
-	K8sClient kubernetes.Interface
-	Logger    micrologger.Logger
+	DynamicClient dynamic.Interface
+	Logger        micrologger.Logger
+	RESTMapper    meta.RESTMapper
+
+	// Settings.
+	// GroupVersionResource identifies the draughtsman CRD's resource, e.g.
+	// {Group: "draughtsman.giantswarm.io", Version: "v1", Resource:
+	// "draughtsmanconfigs"}.
+	GroupVersionResource schema.GroupVersionResource
 }
 
 // DefaultConfig provides a default configuration to create a new TPO service by
@@ -30,80 +48,104 @@ type Config struct {
 func DefaultConfig() Config {
 	return Config{
 		// Dependencies.
-		K8sClient: nil,
-		Logger:    nil,
+		DynamicClient: nil,
+		Logger:        nil,
+		RESTMapper:    nil,
+
+		// Settings.
+		GroupVersionResource: schema.GroupVersionResource{},
 	}
 }
 
 type Service struct {
 	// Dependencies.
-	k8sClient kubernetes.Interface
-	logger    micrologger.Logger
+	dynamicClient dynamic.Interface
+	logger        micrologger.Logger
 
 	// Internals.
-	draughtsmanTPR *tpr.TPR
+	namespaced bool
+
+	// Settings.
+	gvr schema.GroupVersionResource
 }
 
 // New creates a new configured TPO service.
 func New(config Config) (*Service, error) {
 	// Dependencies.
-	if config.K8sClient == nil {
-		return nil, microerror.Maskf(invalidConfigError, "config.K8sClient must not be empty")
+	if config.DynamicClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.DynamicClient must not be empty")
 	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "config.Logger must not be empty")
 	}
+	if config.RESTMapper == nil {
+		return nil, microerror.Maskf(invalidConfigError, "config.RESTMapper must not be empty")
+	}
 
-	var err error
-
-	var draughtsmanTPR *tpr.TPR
-	{
-		tprConfig := tpr.DefaultConfig()
-
-		tprConfig.K8sClient = config.K8sClient
-		tprConfig.Logger = config.Logger
-
-		tprConfig.Name = draughtsmantpr.Name
-		tprConfig.Version = draughtsmantpr.VersionV1
-		tprConfig.Description = draughtsmantpr.Description
+	// Settings.
+	if config.GroupVersionResource.Resource == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.GroupVersionResource must not be empty")
+	}
 
-		draughtsmanTPR, err = tpr.New(tprConfig)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
+	mapping, err := config.RESTMapper.RESTMapping(config.GroupVersionResource.GroupVersion().WithKind(Kind).GroupKind())
+	if err != nil {
+		return nil, microerror.Mask(err)
 	}
 
-	eventer := &Service{
+	service := &Service{
 		// Dependencies.
-		k8sClient: config.K8sClient,
-		logger:    config.Logger,
+		dynamicClient: config.DynamicClient,
+		logger:        config.Logger,
 
 		// Internals.
-		draughtsmanTPR: draughtsmanTPR,
+		namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+
+		// Settings.
+		gvr: config.GroupVersionResource,
 	}
 
-	return eventer, nil
+	return service, nil
 }
 
-func (s *Service) Ensure(tpo draughtsmantpr.CustomObject) error {
-	if tpo.TypeMeta.APIVersion == "" {
-		tpo.TypeMeta.APIVersion = s.draughtsmanTPR.APIVersion()
+func (s *Service) Ensure(tpo *unstructured.Unstructured) error {
+	ctx := log.With(context.Background(), s.logger, "name", Name)
+	logger := log.FromContext(ctx, s.logger)
+
+	if tpo.GetAPIVersion() == "" {
+		tpo.SetAPIVersion(APIVersion)
+	}
+	if tpo.GetKind() == "" {
+		tpo.SetKind(Kind)
 	}
-	if tpo.TypeMeta.Kind == "" {
-		tpo.TypeMeta.Kind = s.draughtsmanTPR.Kind()
+	if tpo.GetName() == "" {
+		tpo.SetName(Name)
 	}
 
-	endpoint := s.draughtsmanTPR.Endpoint(DefaultNamespace) + "/" + Name
-	_, err := s.k8sClient.Core().RESTClient().Post().Body(tpo).AbsPath(endpoint).DoRaw()
+	client := s.resource()
+
+	_, err := client.Update(tpo, metav1.UpdateOptions{})
 	if apierrors.IsNotFound(err) {
-		return microerror.Mask(notFoundError)
-	} else if apierrors.IsAlreadyExists(err) {
-		_, err := s.k8sClient.Core().RESTClient().Put().Body(tpo).AbsPath(endpoint).DoRaw()
-		if apierrors.IsNotFound(err) {
-			return microerror.Mask(notFoundError)
+		logger.Log("debug", "TPO not found, creating it")
+		_, err := client.Create(tpo, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			// Someone else created the TPO between our Update and Create
+			// calls above. Treat this the same as a concurrent Update: tell
+			// the caller to re-fetch and retry its mutation instead of
+			// returning a raw k8s error it won't recognize and dropping the
+			// update.
+			logger.Log("warning", "TPO created concurrently since it was last fetched")
+			return microerror.Mask(conflictError)
 		} else if err != nil {
 			return microerror.Mask(err)
 		}
+		return nil
+	} else if apierrors.IsConflict(err) {
+		// tpo's ResourceVersion no longer matches what is stored, meaning
+		// someone else updated the TPO since the caller last fetched it.
+		// Surface this distinctly so the caller can re-fetch and retry its
+		// mutation instead of silently clobbering the other write.
+		logger.Log("warning", "TPO changed concurrently since it was last fetched")
+		return microerror.Mask(conflictError)
 	} else if err != nil {
 		return microerror.Mask(err)
 	}
@@ -111,21 +153,78 @@ func (s *Service) Ensure(tpo draughtsmantpr.CustomObject) error {
 	return nil
 }
 
-func (s *Service) Get() (draughtsmantpr.CustomObject, error) {
-	endpoint := s.draughtsmanTPR.Endpoint(DefaultNamespace) + "/" + Name
+func (s *Service) Get() (*unstructured.Unstructured, error) {
+	ctx := log.With(context.Background(), s.logger, "name", Name)
+	logger := log.FromContext(ctx, s.logger)
 
-	b, err := s.k8sClient.Core().RESTClient().Get().AbsPath(endpoint).DoRaw()
+	tpo, err := s.resource().Get(Name, metav1.GetOptions{})
 	if apierrors.IsNotFound(err) {
-		return draughtsmantpr.CustomObject{}, microerror.Mask(notFoundError)
+		logger.Log("debug", "TPO not found")
+		return nil, microerror.Mask(notFoundError)
 	} else if err != nil {
-		return draughtsmantpr.CustomObject{}, microerror.Mask(err)
+		return nil, microerror.Mask(err)
 	}
 
-	var tpo draughtsmantpr.CustomObject
-	err = json.Unmarshal(b, &tpo)
+	return tpo, nil
+}
+
+// Watch streams the TPO every time it is added, modified, or deleted,
+// regardless of who wrote it, so consumers can react to external edits
+// rather than only their own Ensure calls.
+func (s *Service) Watch(ctx context.Context) (<-chan *unstructured.Unstructured, error) {
+	ctx = log.With(ctx, s.logger, "name", Name)
+	logger := log.FromContext(ctx, s.logger)
+
+	watcher, err := s.resource().Watch(metav1.ListOptions{
+		FieldSelector: "metadata.name=" + Name,
+	})
 	if err != nil {
-		return draughtsmantpr.CustomObject{}, microerror.Mask(err)
+		return nil, microerror.Mask(err)
 	}
 
-	return tpo, nil
+	tpoChannel := make(chan *unstructured.Unstructured)
+
+	go func() {
+		<-ctx.Done()
+		watcher.Stop()
+	}()
+
+	go func() {
+		defer close(tpoChannel)
+
+		for event := range watcher.ResultChan() {
+			switch event.Type {
+			case watch.Added, watch.Modified, watch.Deleted:
+				// For a Deleted event, event.Object is the TPO's last known
+				// state before removal, so consumers still learn which TPO
+				// disappeared rather than the event being swallowed.
+				tpo, ok := event.Object.(*unstructured.Unstructured)
+				if !ok {
+					continue
+				}
+				logger.Log("debug", "observed TPO watch event", "type", event.Type)
+
+				select {
+				case tpoChannel <- tpo:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return tpoChannel, nil
+}
+
+// resource returns the dynamic client resource interface for the TPO,
+// namespaced under DefaultNamespace when the RESTMapper reports the CRD as a
+// namespaced resource.
+func (s *Service) resource() dynamic.ResourceInterface {
+	resource := s.dynamicClient.Resource(s.gvr)
+
+	if s.namespaced {
+		return resource.Namespace(DefaultNamespace)
+	}
+
+	return resource
 }
@@ -0,0 +1,146 @@
+package tpo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kgotesting "k8s.io/client-go/testing"
+)
+
+var testGVR = schema.GroupVersionResource{
+	Group:    "draughtsman.giantswarm.io",
+	Version:  "v1",
+	Resource: "draughtsmanconfigs",
+}
+
+// stubRESTMapper is a minimal meta.RESTMapper that always reports testGVR as
+// namespaced, without pulling in real discovery machinery.
+type stubRESTMapper struct {
+	meta.RESTMapper
+}
+
+func (m *stubRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	return &meta.RESTMapping{Scope: meta.RESTScopeNamespace}, nil
+}
+
+func newTestService(t *testing.T) *Service {
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{
+		testGVR: "DraughtsmanConfigList",
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds)
+
+	config := DefaultConfig()
+
+	config.DynamicClient = dynamicClient
+	config.Logger = microloggertest.New()
+	config.RESTMapper = &stubRESTMapper{}
+
+	config.GroupVersionResource = testGVR
+
+	service, err := New(config)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	return service
+}
+
+func Test_Service_Get_NotFound(t *testing.T) {
+	service := newTestService(t)
+
+	_, err := service.Get()
+	if !IsNotFound(err) {
+		t.Fatalf("expected not found error got %#v", err)
+	}
+}
+
+func Test_Service_Ensure_CreatesThenUpdates(t *testing.T) {
+	service := newTestService(t)
+
+	tpo := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"projects": []interface{}{},
+		},
+	}}
+
+	err := service.Ensure(tpo)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	created, err := service.Get()
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if created.GetName() != Name {
+		t.Fatalf("expected %#v got %#v", Name, created.GetName())
+	}
+	if created.GetAPIVersion() != APIVersion {
+		t.Fatalf("expected %#v got %#v", APIVersion, created.GetAPIVersion())
+	}
+
+	projects, _, err := unstructured.NestedSlice(created.Object, "spec", "projects")
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if !reflect.DeepEqual(projects, []interface{}{}) {
+		t.Fatalf("expected %#v got %#v", []interface{}{}, projects)
+	}
+
+	if err := unstructured.SetNestedSlice(created.Object, []interface{}{
+		map[string]interface{}{"id": "1", "name": "api", "ref": "sha"},
+	}, "spec", "projects"); err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	err = service.Ensure(created)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	updated, err := service.Get()
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	projects, _, err = unstructured.NestedSlice(updated.Object, "spec", "projects")
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+	if len(projects) != 1 {
+		t.Fatalf("expected %#v got %#v", 1, len(projects))
+	}
+}
+
+// Test_Service_Ensure_CreateRace covers two processes racing to create the
+// TPO for the first time: both see it missing on Update, and the loser's
+// Create fails with IsAlreadyExists rather than IsConflict. Ensure must
+// still report this as conflictError so the caller re-fetches and retries,
+// instead of returning the raw k8s error and dropping the update.
+func Test_Service_Ensure_CreateRace(t *testing.T) {
+	service := newTestService(t)
+
+	service.dynamicClient.(interface {
+		PrependReactor(verb, resource string, reaction kgotesting.ReactionFunc)
+	}).PrependReactor("create", "draughtsmanconfigs", func(action kgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewAlreadyExists(schema.GroupResource{Group: testGVR.Group, Resource: testGVR.Resource}, Name)
+	})
+
+	tpo := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"projects": []interface{}{},
+		},
+	}}
+
+	err := service.Ensure(tpo)
+	if !IsConflict(err) {
+		t.Fatalf("expected conflict error got %#v", err)
+	}
+}